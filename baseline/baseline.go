@@ -0,0 +1,166 @@
+// Baselines record known findings, so only new findings are reported while the known ones are fixed.
+package baseline
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/report"
+)
+
+// Version of the baseline file format
+const Version = 1
+
+// Identifies a known finding. Positions aren't part of it, so findings stay known when lines shift.
+type Entry struct {
+	Rule string `json:"rule"`
+	// Path relative to the scanned directory, with forward slashes
+	File    string `json:"file"`
+	Pointer string `json:"pointer"`
+	// SHA-256 of the message, so a different problem of the same value is a new finding
+	MessageHash string `json:"messageHash"`
+}
+
+// Positions in messages of files that can't be parsed, e.g. `yaml: line 2: ...`
+var messagePosition = regexp.MustCompile(`\b(line|column) \d+`)
+
+// Replaces the roots in messages, so the message hash doesn't depend on the location of the scanned tree
+const rootPlaceholder = "<root>"
+
+// Returns the fingerprint of the finding, found by scanning one of the roots.
+// The path is relative to the root holding the file, so it doesn't depend on the working directory
+// or the way the root is given. The path of the file, absolute paths and `file://` URIs of the roots
+// and positions of files that can't be parsed aren't part of the message hash.
+func Fingerprint(finding report.Finding, roots []string) Entry {
+	message := withoutRoots(strings.ReplaceAll(finding.Message, finding.File, ""), roots)
+	if finding.Rule == document.ParseErrorRule {
+		message = messagePosition.ReplaceAllString(message, "$1")
+	}
+	hash := sha256.Sum256([]byte(message))
+	return Entry{
+		Rule:        finding.Rule,
+		File:        relativePath(finding.File, roots),
+		Pointer:     finding.Pointer,
+		MessageHash: hex.EncodeToString(hash[:]),
+	}
+}
+
+// Replaces the `file://` URIs and the absolute paths of the roots in the message, e.g. paths of other files
+// in errors of the store. Inner roots are replaced first.
+func withoutRoots(message string, roots []string) string {
+	absoluteRoots := []string{}
+	for _, root := range roots {
+		if absoluteRoot, err := filepath.Abs(root); err == nil {
+			absoluteRoots = append(absoluteRoots, absoluteRoot)
+		}
+	}
+	sort.SliceStable(absoluteRoots, func(i, j int) bool {
+		return len(absoluteRoots[i]) > len(absoluteRoots[j])
+	})
+	for _, absoluteRoot := range absoluteRoots {
+		message = strings.ReplaceAll(message, document.URI(absoluteRoot), rootPlaceholder)
+		message = strings.ReplaceAll(message, absoluteRoot, rootPlaceholder)
+	}
+	return message
+}
+
+// Returns the path relative to the innermost root holding it, or the cleaned path if no root holds it.
+func relativePath(path string, roots []string) string {
+	relativePath := filepath.Clean(path)
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.ToSlash(relativePath)
+	}
+	innermost := ""
+	for _, root := range roots {
+		absoluteRoot, err := filepath.Abs(root)
+		if err != nil || len(absoluteRoot) < len(innermost) {
+			continue
+		}
+		if relative, err := filepath.Rel(absoluteRoot, absolutePath); err == nil && relative != ".." && !strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+			innermost, relativePath = absoluteRoot, relative
+		}
+	}
+	return filepath.ToSlash(relativePath)
+}
+
+// Known findings, the same finding may be known several times
+type Baseline struct {
+	Version  int     `json:"version"`
+	Findings []Entry `json:"findings"`
+}
+
+// Returns the baseline knowing the findings of the roots.
+func New(findings []report.Finding, roots []string) Baseline {
+	baseline := Baseline{Version: Version, Findings: []Entry{}}
+	for _, finding := range findings {
+		baseline.Findings = append(baseline.Findings, Fingerprint(finding, roots))
+	}
+	sort.SliceStable(baseline.Findings, func(i, j int) bool {
+		a, b := baseline.Findings[i], baseline.Findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Pointer != b.Pointer {
+			return a.Pointer < b.Pointer
+		}
+		if a.Rule != b.Rule {
+			return a.Rule < b.Rule
+		}
+		return a.MessageHash < b.MessageHash
+	})
+	return baseline
+}
+
+// Reads the baseline file.
+func Load(path string) (Baseline, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return Baseline{}, err
+	}
+	baseline := Baseline{}
+	if err := json.Unmarshal(content, &baseline); err != nil {
+		return Baseline{}, fmt.Errorf("%s: %s", path, err)
+	}
+	if baseline.Version != Version {
+		return Baseline{}, fmt.Errorf("%s: unsupported baseline version: %d", path, baseline.Version)
+	}
+	return baseline, nil
+}
+
+// Writes the baseline file, entries are sorted so the file can be reviewed and diffed.
+func (baseline Baseline) Write(path string) error {
+	content, err := json.MarshalIndent(baseline, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, append(content, '\n'), 0644)
+}
+
+// Returns the findings of the roots that aren't known by the baseline and the number of known ones.
+// A finding known once hides a single occurrence, further occurrences of the same finding are new.
+func (baseline Baseline) Filter(findings []report.Finding, roots []string) ([]report.Finding, int) {
+	known := map[Entry]int{}
+	for _, entry := range baseline.Findings {
+		known[entry]++
+	}
+	newFindings, knownCount := []report.Finding{}, 0
+	for _, finding := range findings {
+		fingerprint := Fingerprint(finding, roots)
+		if known[fingerprint] > 0 {
+			known[fingerprint]--
+			knownCount++
+			continue
+		}
+		newFindings = append(newFindings, finding)
+	}
+	return newFindings, knownCount
+}
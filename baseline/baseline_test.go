@@ -0,0 +1,144 @@
+package baseline_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/clearcodehq/openapi-linter/baseline"
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/report"
+	validate_examples "github.com/clearcodehq/openapi-linter/validate-examples"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	known := report.Finding{File: "specs/openapi.yaml", Pointer: "/paths/~1users", Line: 10, Column: 5, Rule: "valid-example", Message: "id: Invalid type"}
+	known2 := report.Finding{File: "specs/openapi.yaml", Pointer: "/components/schemas/Old", Line: 20, Rule: "unused-component", Message: "unused"}
+	roots := []string{"specs"}
+	existing := baseline.New([]report.Finding{known, known2}, roots)
+
+	shifted := known
+	shifted.Line, shifted.Column = 14, 7
+	changed := known
+	changed.Message = "name: Invalid type"
+	duplicate := known2
+
+	// WHEN
+	findings, knownCount := existing.Filter([]report.Finding{shifted, changed, known2, duplicate}, roots)
+
+	// THEN
+	Assert.Equal(2, knownCount, "Findings are known regardless of their positions")
+	Assert.Equal([]report.Finding{changed, duplicate}, findings, "Findings with other messages and further occurrences of known findings are new")
+}
+
+func TestFingerprint(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	finding := report.Finding{File: filepath.Join("specs", "api", "openapi.yaml"), Pointer: "/paths", Rule: "valid-spec", Message: "invalid"}
+	absoluteRoot, _ := filepath.Abs("specs")
+	absoluteFinding := finding
+	absoluteFinding.File = filepath.Join(absoluteRoot, "api", "openapi.yaml")
+
+	// WHEN
+	fingerprint := baseline.Fingerprint(finding, []string{"specs"})
+
+	// THEN
+	Assert.Equal("api/openapi.yaml", fingerprint.File, "Paths are relative to the root")
+	Assert.Equal(fingerprint, baseline.Fingerprint(absoluteFinding, []string{absoluteRoot}), "Fingerprints don't depend on the way the root is given")
+	Assert.Equal(fingerprint, baseline.Fingerprint(absoluteFinding, []string{"specs", filepath.Join("specs", "api", "..")}))
+
+	// GIVEN
+	parseError := report.Finding{File: "specs/broken.yaml", Rule: "parse-error", Message: "can't unmarshal contents: specs/broken.yaml: yaml: line 2: did not find expected node content"}
+	shifted := parseError
+	shifted.Message = "can't unmarshal contents: specs/broken.yaml: yaml: line 7: did not find expected node content"
+
+	// THEN
+	Assert.Equal(baseline.Fingerprint(parseError, nil).MessageHash, baseline.Fingerprint(shifted, nil).MessageHash, "Positions of parse errors aren't part of the fingerprint")
+}
+
+func TestFingerprintOfMovedTree(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN the same tree in two locations, with an external example that can't be loaded
+	spec := `openapi: 3.0.3
+info: {title: Users, version: 1.0.0}
+paths:
+  /users:
+    get:
+      responses:
+        "200":
+          description: Users
+          content:
+            application/json:
+              schema: {type: object}
+              examples:
+                missing: {externalValue: "examples/missing.json"}
+`
+	fingerprints := []baseline.Entry{}
+	for _, location := range []string{"checkout", "moved checkout"} {
+		dir, err := ioutil.TempDir("", location)
+		Assert.Nil(err)
+		defer os.RemoveAll(dir)
+		root := filepath.Join(dir, "specs")
+		Assert.Nil(os.Mkdir(root, 0755))
+		Assert.Nil(ioutil.WriteFile(filepath.Join(root, "openapi.yaml"), []byte(spec), 0644))
+
+		// WHEN
+		errors, _ := validate_examples.ScanForExampleErrors(root)
+		Assert.Len(errors, 1)
+		Assert.Contains(errors[0].Error(), root, "The message holds an absolute path")
+		fingerprints = append(fingerprints, baseline.Fingerprint(errors[0].Finding(), []string{root}))
+	}
+
+	// THEN
+	Assert.Equal(fingerprints[0], fingerprints[1], "Fingerprints don't depend on the location of the tree")
+
+	// GIVEN
+	root, _ := filepath.Abs(filepath.Join("specs", "user schemas"))
+	finding := report.Finding{File: "other.yaml", Rule: "no-unresolved-ref", Message: document.URI(filepath.Join(root, "user.yaml")) + ": missing"}
+	movedRoot, _ := filepath.Abs(filepath.Join("moved", "user schemas"))
+	moved := finding
+	moved.Message = document.URI(filepath.Join(movedRoot, "user.yaml")) + ": missing"
+
+	// THEN
+	Assert.Equal(baseline.Fingerprint(finding, []string{root}), baseline.Fingerprint(moved, []string{movedRoot}), "URIs of the roots aren't part of the message hash")
+}
+
+func TestWriteAndLoad(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	dir, err := ioutil.TempDir("", "baseline")
+	Assert.Nil(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "baseline.json")
+	written := baseline.New([]report.Finding{
+		{File: "b.yaml", Pointer: "/a", Rule: "valid-spec", Message: "invalid"},
+		{File: "a.yaml", Pointer: "/b", Rule: "valid-spec", Message: "invalid"},
+	}, []string{"."})
+
+	// WHEN
+	err = written.Write(path)
+	loaded, loadErr := baseline.Load(path)
+
+	// THEN
+	Assert.Nil(err)
+	Assert.Nil(loadErr)
+	Assert.Equal(written, loaded)
+	Assert.Equal("a.yaml", loaded.Findings[0].File, "Entries are sorted by the file")
+
+	// GIVEN
+	Assert.Nil(ioutil.WriteFile(path, []byte(`{"version": 2, "findings": []}`), 0644))
+
+	// WHEN
+	_, err = baseline.Load(path)
+
+	// THEN
+	Assert.EqualError(err, path+": unsupported baseline version: 2")
+}
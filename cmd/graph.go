@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"fmt"
+	export_graph "github.com/clearcodehq/openapi-linter/export-graph"
+	"github.com/spf13/cobra"
+)
+
+var (
+	graphFormat string
+	graphLevel  string
+	graphRoot   string
+)
+
+var graphCmd = &cobra.Command{
+	Use:          "graph",
+	Short:        "Write the `$ref` dependency graph of all JSON and YAML files in the directory as Graphviz DOT or JSON.",
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return export_graph.ValidateOptions(graphLevel, graphFormat)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		graph, validationErrors, err := export_graph.BuildDependencyGraph(args[0], graphRoot, export_graph.Level(graphLevel))
+		if err != nil {
+			return fmt.Errorf("Couldn't build the graph: %s", err)
+		}
+		// the graph is written to stdout, so it can be piped to Graphviz
+		for _, validationError := range validationErrors {
+			fmt.Fprintf(cmd.OutOrStderr(), "Skipped %s: %s\n", validationError.Finding().Location(), validationError.Err)
+		}
+
+		if graphFormat == export_graph.FormatJSON {
+			return export_graph.WriteJSON(cmd.OutOrStdout(), graph)
+		}
+		return export_graph.WriteDOT(cmd.OutOrStdout(), graph)
+	},
+}
+
+func init() {
+	graphCmd.Flags().StringVar(&graphFormat, "format", export_graph.FormatDOT, "output format: dot (Graphviz) or json (adjacency lists)")
+	graphCmd.Flags().StringVar(&graphLevel, "level", string(export_graph.LevelComponent), "nodes of the graph: component (components, path items and documents) or file")
+	graphCmd.Flags().StringVar(&graphRoot, "root", "", "scope the graph to the document or component reachable from it, e.g. openapi.yaml#/components/schemas/User (relative to the directory)")
+	rootCmd.AddCommand(graphCmd)
+}
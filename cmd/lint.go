@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"github.com/clearcodehq/openapi-linter/config"
+	"github.com/clearcodehq/openapi-linter/lint"
+	"github.com/clearcodehq/openapi-linter/report"
+	"github.com/spf13/cobra"
+)
+
+var lintRules []string
+
+// Settings of the run, resolved by PreRunE
+var (
+	lintConfig  *config.Config
+	lintEnabled []lint.Rule
+	lintOptions lint.Options
+)
+
+var lintCmd = &cobra.Command{
+	Use:          "lint [directory]",
+	Short:        "Run all enabled rules over all JSON and YAML files in the directory (or the configured roots) in one pass.",
+	SilenceUsage: true,
+	Args:         cobra.MaximumNArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		var err error
+		if lintConfig, err = loadConfig(); err != nil {
+			return fmt.Errorf("Couldn't load the configuration: %s", err)
+		}
+		if lintEnabled, lintOptions, err = lintConfig.Lint(); err != nil {
+			return fmt.Errorf("Invalid configuration: %s", err)
+		}
+		if len(lintRules) > 0 {
+			if lintEnabled, err = lint.SelectRules(lintRules); err != nil {
+				return err
+			}
+		}
+		if !cmd.Flags().Changed("format") && len(lintConfig.Format) > 0 {
+			outputFormat = lintConfig.Format
+		}
+		return validateOutputFlags()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		roots := lintConfig.Roots
+		if len(args) > 0 {
+			roots = args
+		}
+		if len(roots) == 0 {
+			roots = []string{"."}
+		}
+
+		result := lint.Result{Findings: []report.Finding{}, Checks: []report.Check{}}
+		for _, root := range roots {
+			rootResult, err := lint.Lint(root, lintEnabled, lintOptions)
+			if err != nil {
+				return fmt.Errorf("Couldn't scan the directory: %s", err)
+			}
+			result.Add(rootResult)
+		}
+		result.Findings = report.Sort(result.Findings)
+		result.Suppressed = report.SortSuppressed(result.Suppressed)
+		findings, done, err := applyBaseline(cmd, roots, result.Findings)
+		if err != nil || done {
+			return err
+		}
+
+		written, err := writeFindings(cmd, lint.ReportRules(lintEnabled, lintOptions), result.Files, result.Checks, findings, result.Suppressed)
+		if err != nil {
+			return err
+		}
+
+		if !written {
+			for _, finding := range findings {
+				cmd.Printf("%s: %s [%s] %s\n", finding.Location(), finding.Severity, finding.Rule, finding.Message)
+			}
+			displaySuppressed(cmd.OutOrStderr(), result.Suppressed)
+		}
+		return checkFindings(findings, "The linting has failed.")
+	},
+}
+
+func init() {
+	addOutputFlags(lintCmd)
+	lintCmd.Flags().StringVar(&cfgFile, "config", "",
+		"configuration file (default: "+config.FileName+" in the working directory or the nearest parent directory)")
+	lintCmd.Flags().StringSliceVar(&lintRules, "rules", nil, "IDs of the rules to run (comma separated), overrides the rules enabled by the configuration")
+	rootCmd.AddCommand(lintCmd)
+}
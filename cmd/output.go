@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/clearcodehq/openapi-linter/baseline"
+	"github.com/clearcodehq/openapi-linter/report"
+	"github.com/spf13/cobra"
+)
+
+var (
+	outputFormat string
+	failOn       string
+	maxWarnings  int
+
+	baselineFile      string
+	writeBaselineFile string
+)
+
+// Value of --fail-on that never fails the command because of findings
+const failOnNone = "none"
+
+// Registers the output format flag and the fail thresholds on the command.
+func addOutputFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&outputFormat, "format", report.FormatText,
+		fmt.Sprintf("Output format, one of: %s", strings.Join(report.Formats, ", ")))
+	cmd.Flags().StringVar(&failOn, "fail-on", report.SeverityError,
+		fmt.Sprintf("Fail if there's a finding of this severity or a more severe one, one of: %s, %s", strings.Join(report.Severities, ", "), failOnNone))
+	cmd.Flags().IntVar(&maxWarnings, "max-warnings", -1,
+		"Fail if there are more warnings than this, unlimited if it's negative")
+	cmd.Flags().StringVar(&baselineFile, "baseline", "",
+		"Baseline file written by --write-baseline, only findings that aren't in it are reported")
+	cmd.Flags().StringVar(&writeBaselineFile, "write-baseline", "",
+		"Record all current findings in the baseline file instead of reporting them")
+}
+
+// Checks the output format, the fail threshold and the baseline flags.
+func validateOutputFlags() error {
+	if len(baselineFile) > 0 && len(writeBaselineFile) > 0 {
+		return fmt.Errorf("--baseline and --write-baseline can't be used together")
+	}
+	if failOn != failOnNone {
+		if err := report.ValidateSeverity(failOn); err != nil {
+			return fmt.Errorf("invalid --fail-on: %s", err)
+		}
+	}
+	return report.ValidateFormat(outputFormat)
+}
+
+// Applies the baseline flags to the findings.
+// With --write-baseline the findings are recorded and the command is done, it doesn't fail because of them.
+// With --baseline the findings known by the baseline are left out. Returns the findings to report.
+// Findings are recorded with paths relative to the scanned roots.
+func applyBaseline(cmd *cobra.Command, roots []string, findings []report.Finding) ([]report.Finding, bool, error) {
+	if len(writeBaselineFile) > 0 {
+		if err := baseline.New(findings, roots).Write(writeBaselineFile); err != nil {
+			return nil, true, fmt.Errorf("Couldn't write the baseline: %s", err)
+		}
+		fmt.Fprintf(cmd.OutOrStderr(), "Findings recorded in the baseline %s: %d\n", writeBaselineFile, len(findings))
+		return findings, true, nil
+	}
+	if len(baselineFile) == 0 {
+		return findings, false, nil
+	}
+	known, err := baseline.Load(baselineFile)
+	if err != nil {
+		return nil, false, fmt.Errorf("Couldn't load the baseline: %s", err)
+	}
+	findings, knownCount := known.Filter(findings, roots)
+	if knownCount > 0 && outputFormat == report.FormatText {
+		fmt.Fprintf(cmd.OutOrStderr(), "Findings in the baseline, not reported: %d\n", knownCount)
+	}
+	return findings, false, nil
+}
+
+// Writes findings in a machine-readable format selected by the --format flag, suppressed findings in their own section.
+// Returns false if the text format is selected and the command should display findings by itself.
+// Scanned files and checks are only used by formats that list passed checks too. JUnit reports have no section for suppressed findings,
+// they are left out of them.
+func writeFindings(cmd *cobra.Command, rules []report.Rule, files []string, checks []report.Check, findings []report.Finding, suppressed []report.Suppressed) (bool, error) {
+	switch outputFormat {
+	case report.FormatJSON:
+		return true, report.WriteJSONWithSuppressed(cmd.OutOrStdout(), findings, suppressed)
+	case report.FormatSARIF:
+		return true, report.WriteSARIFWithSuppressed(cmd.OutOrStdout(), rules, findings, suppressed)
+	case report.FormatJUnit:
+		return true, report.WriteJUnit(cmd.OutOrStdout(), files, checks, findings)
+	}
+	return false, nil
+}
+
+// Displays suppressed findings after the other findings in the text format, to the same writer.
+func displaySuppressed(w io.Writer, suppressed []report.Suppressed) {
+	if len(suppressed) > 0 {
+		fmt.Fprintf(w, "Suppressed:\n")
+	}
+	for _, finding := range suppressed {
+		fmt.Fprintf(w, "%s: [%s] %s (%s)\n", finding.Location(), finding.Rule, finding.Message, finding.Reason)
+	}
+}
+
+// Returned by commands that ran fine but found problems, the process exits with 1 (and with 2 on other errors).
+type findingsError struct {
+	message string
+}
+
+func (err *findingsError) Error() string {
+	return err.message
+}
+
+// Returns the error failing the command if a finding reaches the --fail-on threshold
+// or there are more warnings than --max-warnings.
+func checkFindings(findings []report.Finding, message string) error {
+	warnings := 0
+	failed := false
+	for _, finding := range findings {
+		if finding.Severity == report.SeverityWarning {
+			warnings++
+		}
+		if failOn != failOnNone && report.AtLeast(finding.Severity, failOn) {
+			failed = true
+		}
+	}
+	if failed {
+		return &findingsError{message}
+	}
+	if maxWarnings >= 0 && warnings > maxWarnings {
+		return &findingsError{fmt.Sprintf("%s Too many warnings: %d (at most %d allowed).", message, warnings, maxWarnings)}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package cmd
 import (
         "os"
 
+        "github.com/clearcodehq/openapi-linter/config"
         "github.com/spf13/cobra"
 )
 
@@ -14,18 +15,34 @@ var rootCmd = &cobra.Command{
         Short: "A tool that scans the provided directory and validates all JSON Schema objects inside it.",
 }
 
+// Exit codes of the process
+const (
+        exitFindings = 1
+        exitFailure  = 2
+)
+
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCMD.
+// Exits with 1 if findings fail the command, and with 2 if the tool can't run (e.g. invalid flags or configuration).
 func Execute() {
         if err := rootCmd.Execute(); err != nil {
-                os.Exit(1)
+                if _, isFindingsError := err.(*findingsError); isFindingsError {
+                        os.Exit(exitFindings)
+                }
+                os.Exit(exitFailure)
         }
 }
 
-func init() {
-        cobra.OnInitialize()
-
-        // Cobra also supports local flags, which will only run
-        // when this action is called directly.
-        // rootCMD.Flags().BoolP("toggle", "t", false, "Help message for toggle")
-}
\ No newline at end of file
+// Loads the configuration file given by the --config flag, or the one discovered upward from the working directory.
+// Returns an empty configuration if there's no configuration file.
+func loadConfig() (*config.Config, error) {
+        path := cfgFile
+        if len(path) == 0 {
+                found, err := config.Find(".")
+                if err != nil || len(found) == 0 {
+                        return &config.Config{}, err
+                }
+                path = found
+        }
+        return config.Load(path)
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/lint"
+	"github.com/clearcodehq/openapi-linter/report"
+	"github.com/clearcodehq/openapi-linter/validate"
+	"github.com/spf13/cobra"
+)
+
+// A command reporting findings of a scan of the directory given as its argument
+type scanCommand struct {
+	// Rules of the findings, listed by reports with the rule of invalid suppressions
+	rules []report.Rule
+	// Scans the directory loading documents from the store, returns the findings and the list of checked values
+	scan func(store *document.Store, dir string) ([]report.Finding, []report.Check, error)
+	// Displays findings in the text format
+	display func(w io.Writer, findings []report.Finding)
+	// Writer of the text format, the standard error if it's false
+	stdout bool
+	// Message of the error failing the command because of findings
+	failure string
+}
+
+// Runs the scan and reports its findings: suppressed findings are moved out, the baseline is applied,
+// findings are written in the selected format and the command fails on findings reaching the thresholds.
+// Suppressions are read from the documents already loaded by the scan.
+func runScan(cmd *cobra.Command, dir string, command scanCommand) error {
+	store := document.NewStore()
+	findings, checks, err := command.scan(store, dir)
+	if err != nil {
+		return fmt.Errorf("Couldn't scan the directory: %s", err)
+	}
+	files, err := validate.FindJsonFiles(dir)
+	if err != nil {
+		return fmt.Errorf("Couldn't scan the directory: %s", err)
+	}
+
+	findings, suppressed := lint.SuppressFindings(store, files, findings)
+	findings, done, err := applyBaseline(cmd, []string{dir}, findings)
+	if err != nil || done {
+		return err
+	}
+	written, err := writeFindings(cmd, append(command.rules, report.Rule{ID: lint.InvalidIgnoreRule, Description: lint.InvalidIgnoreRuleDescription}), files, checks, findings, suppressed)
+	if err != nil {
+		return err
+	}
+
+	if !written {
+		w := cmd.OutOrStderr()
+		if command.stdout {
+			w = cmd.OutOrStdout()
+		}
+		command.display(w, findings)
+		displaySuppressed(w, suppressed)
+	}
+	return checkFindings(findings, command.failure)
+}
+
+// Converts validation errors to findings.
+func validationFindings(validationErrors []validate.ValidationError) []report.Finding {
+	findings := []report.Finding{}
+	for _, validationError := range validationErrors {
+		findings = append(findings, validationError.Finding())
+	}
+	return findings
+}
+
+// Displays findings with the file, the JSON Pointer and the message on separate lines.
+func displayErrors(w io.Writer, findings []report.Finding) {
+	for _, finding := range findings {
+		fmt.Fprintf(w, "File: %s\nJSONPointer: %s\nError message:\n%s\n", finding.Location(), finding.Pointer, finding.Message)
+	}
+}
+
+// Displays every finding on a single line, as `file:line:column: message`.
+func displayMessages(w io.Writer, findings []report.Finding) {
+	for _, finding := range findings {
+		fmt.Fprintf(w, "%s: %s\n", finding.Location(), finding.Message)
+	}
+}
@@ -1,35 +1,47 @@
 package cmd
 
 import (
-	"fmt"
 	"github.com/spf13/cobra"
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/references"
+	"github.com/clearcodehq/openapi-linter/report"
 	"github.com/clearcodehq/openapi-linter/validate"
 )
 
+var validateMode string
+
 var validateCmd = &cobra.Command{
 	Use: "validate",
-	Short: "Scan all JSON files in the directory and validate all JSON Schemas found in those files.",
+	Short: "Scan all JSON and YAML files in the directory and validate all JSON Schemas found in those files.",
 	SilenceUsage: true,
 	Args: cobra.ExactArgs(1),
-	RunE: func (cmd *cobra.Command, args []string) error {
-		validationErrors, err := validate.ValidateAllSchemasInDir(args[0]);
-		if err != nil {
-			return fmt.Errorf("Couldn't parse some files.")
-		}
-
-		if len(validationErrors) > 0{
-			displayErrors(&validationErrors)
-			return fmt.Errorf("The validation has failed.")
+	PreRunE: func (cmd *cobra.Command, args []string) error {
+		if err := validate.ValidateMode(validate.Mode(validateMode)); err != nil {
+			return err
 		}
-		return nil;
+		return validateOutputFlags()
+	},
+	RunE: func (cmd *cobra.Command, args []string) error {
+		return runScan(cmd, args[0], scanCommand{
+			rules: []report.Rule{
+				{ID: validate.Rule, Description: validate.RuleDescription},
+				{ID: validate.SchemaValuesRule, Description: validate.SchemaValuesRuleDescription},
+				{ID: references.CycleRule, Description: references.CycleRuleDescription},
+				{ID: document.ParseErrorRule, Description: document.ParseErrorRuleDescription},
+			},
+			scan: func(store *document.Store, dir string) ([]report.Finding, []report.Check, error) {
+				validationErrors, checks, err := validate.ValidateAllSchemasInStore(store, dir, validate.Mode(validateMode))
+				return validationFindings(validationErrors), checks, err
+			},
+			display: displayErrors,
+			stdout: true,
+			failure: "The validation has failed.",
+		})
 	},
-}
-func displayErrors(errors *map[string] validate.ValidationError) {
-	for _, err := range *errors {
-		fmt.Printf("File: %s\nJSONPath: %s\nError message:\n%s", err.FilePath, err.JsonPath, err.Err)
-	}
 }
 
 func init() {
+	validateCmd.Flags().StringVar(&validateMode, "mode", string(validate.ModeHeuristic), "where to look for schemas: heuristic (every nested object) or openapi (schema locations of OpenAPI documents)")
+	addOutputFlags(validateCmd)
 	rootCmd.AddCommand(validateCmd)
-}
\ No newline at end of file
+}
@@ -1,8 +1,10 @@
 package cmd
 
 import (
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/report"
+	"github.com/clearcodehq/openapi-linter/validate"
 	validate_examples "github.com/clearcodehq/openapi-linter/validate-examples"
-	"fmt"
 	"github.com/spf13/cobra"
 )
 
@@ -11,18 +13,31 @@ var validateExamplesCmd = &cobra.Command{
 	Short:        "Validate if an example matches the schema defined in the API spec.",
 	SilenceUsage: true,
 	Args:         cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return validateOutputFlags()
+	},
 	RunE: func(cmd *cobra.Command, args []string) error {
-		errors := validate_examples.ScanForExamples(args[0])
-		if len(errors) > 0 {
-			for _, err := range errors {
-				cmd.Println(err)
-			}
-			return fmt.Errorf("Validation errors found.")
-		}
-		return nil
+		return runScan(cmd, args[0], scanCommand{
+			rules: []report.Rule{
+				{ID: validate_examples.Rule, Description: validate_examples.RuleDescription},
+				{ID: validate.SchemaValuesRule, Description: validate.SchemaValuesRuleDescription},
+				{ID: document.ParseErrorRule, Description: document.ParseErrorRuleDescription},
+			},
+			scan: func(store *document.Store, dir string) ([]report.Finding, []report.Check, error) {
+				exampleErrors, checks, err := validate_examples.ScanStoreForExampleErrors(store, dir)
+				findings := []report.Finding{}
+				for _, exampleError := range exampleErrors {
+					findings = append(findings, exampleError.Finding())
+				}
+				return findings, checks, err
+			},
+			display: displayMessages,
+			failure: "Validation errors found.",
+		})
 	},
 }
 
 func init() {
+	addOutputFlags(validateExamplesCmd)
 	rootCmd.AddCommand(validateExamplesCmd)
 }
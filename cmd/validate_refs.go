@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/references"
+	"github.com/clearcodehq/openapi-linter/report"
+	validate_refs "github.com/clearcodehq/openapi-linter/validate-refs"
+	"github.com/spf13/cobra"
+)
+
+var validateRefsCmd = &cobra.Command{
+	Use:          "validate-refs",
+	Short:        "Check that every `$ref` of all JSON and YAML files in the directory points to an existing file and value.",
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return validateOutputFlags()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runScan(cmd, args[0], scanCommand{
+			rules: []report.Rule{
+				{ID: references.UnresolvedRule, Description: references.UnresolvedRuleDescription},
+				{ID: document.ParseErrorRule, Description: document.ParseErrorRuleDescription},
+			},
+			scan: func(store *document.Store, dir string) ([]report.Finding, []report.Check, error) {
+				validationErrors, checks, err := validate_refs.ValidateAllRefsInStore(store, dir)
+				return validationFindings(validationErrors), checks, err
+			},
+			display: displayErrors,
+			stdout:  true,
+			failure: "The validation has failed.",
+		})
+	},
+}
+
+func init() {
+	addOutputFlags(validateRefsCmd)
+	rootCmd.AddCommand(validateRefsCmd)
+}
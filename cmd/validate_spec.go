@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/report"
+	validate_spec "github.com/clearcodehq/openapi-linter/validate-spec"
+	"github.com/spf13/cobra"
+)
+
+var validateSpecCmd = &cobra.Command{
+	Use:          "validate-spec",
+	Short:        "Validate all OpenAPI documents in the directory against the official OpenAPI 2.0, 3.0 and 3.1 schemas.",
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return validateOutputFlags()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runScan(cmd, args[0], scanCommand{
+			rules: []report.Rule{
+				{ID: validate_spec.Rule, Description: validate_spec.RuleDescription},
+				{ID: document.ParseErrorRule, Description: document.ParseErrorRuleDescription},
+			},
+			scan: func(store *document.Store, dir string) ([]report.Finding, []report.Check, error) {
+				validationErrors, checks, err := validate_spec.ValidateAllSpecsInStore(store, dir)
+				return validationFindings(validationErrors), checks, err
+			},
+			display: displayErrors,
+			stdout:  true,
+			failure: "The validation has failed.",
+		})
+	},
+}
+
+func init() {
+	addOutputFlags(validateSpecCmd)
+	rootCmd.AddCommand(validateSpecCmd)
+}
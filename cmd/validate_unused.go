@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/references"
+	"github.com/clearcodehq/openapi-linter/report"
+	validate_unused "github.com/clearcodehq/openapi-linter/validate-unused"
+	"github.com/spf13/cobra"
+)
+
+var unusedAllowlist []string
+
+var validateUnusedCmd = &cobra.Command{
+	Use:          "validate-unused",
+	Short:        "Report components and JSON and YAML files in the directory that no operation references.",
+	SilenceUsage: true,
+	Args:         cobra.ExactArgs(1),
+	PreRunE: func(cmd *cobra.Command, args []string) error {
+		return validateOutputFlags()
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runScan(cmd, args[0], scanCommand{
+			rules: []report.Rule{
+				{ID: references.UnusedComponentRule, Description: references.UnusedComponentRuleDescription},
+				{ID: references.OrphanedFileRule, Description: references.OrphanedFileRuleDescription},
+				{ID: document.ParseErrorRule, Description: document.ParseErrorRuleDescription},
+			},
+			scan: func(store *document.Store, dir string) ([]report.Finding, []report.Check, error) {
+				validationErrors, checks, err := validate_unused.FindUnusedInStore(store, dir, unusedAllowlist)
+				return validationFindings(validationErrors), checks, err
+			},
+			display: displayErrors,
+			stdout:  true,
+			failure: "The validation has failed.",
+		})
+	},
+}
+
+func init() {
+	addOutputFlags(validateUnusedCmd)
+	validateUnusedCmd.Flags().StringSliceVar(&unusedAllowlist, "allow", nil, "intentionally public components and files, `<file glob>` or `<file glob>#<JSON Pointer glob>` relative to the directory (repeatable)")
+	rootCmd.AddCommand(validateUnusedCmd)
+}
@@ -0,0 +1,266 @@
+// Project configuration read from `.openapi-linter.yaml`.
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/clearcodehq/openapi-linter/lint"
+	"gopkg.in/yaml.v3"
+)
+
+// Name of the configuration file discovered upward from the working directory
+const FileName = ".openapi-linter.yaml"
+
+// Value of a rule that disables it, e.g. `unused-component: off`
+const Off = "off"
+
+// Settings of the linter, every setting is optional.
+// Paths of roots are relative to the file that declares them, globs are relative to every root.
+type Config struct {
+	// Paths of configuration files that this one overrides, relative to this file
+	Extends stringList `yaml:"extends"`
+	// Directories to scan
+	Roots stringList `yaml:"roots"`
+	// Files to scan and files to skip, e.g. `**/*.yaml` and `vendor/**`
+	Include stringList `yaml:"include"`
+	Exclude stringList `yaml:"exclude"`
+	// Files holding fragments of documents, e.g. `**/*.partial.yaml`, examples in them aren't validated
+	Partials stringList `yaml:"partials"`
+	// Output format, e.g. sarif
+	Format string `yaml:"format"`
+	// Settings of rules by their IDs
+	Rules map[string]RuleConfig `yaml:"rules"`
+}
+
+// Settings of a rule. Written as a mapping or as a single value: the severity or `off`.
+type RuleConfig struct {
+	Enabled  *bool                  `yaml:"enabled"`
+	Severity string                 `yaml:"severity"`
+	Options  map[string]interface{} `yaml:"options"`
+}
+
+func (ruleConfig *RuleConfig) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		var value string
+		if err := node.Decode(&value); err != nil {
+			return err
+		}
+		if value == Off {
+			enabled := false
+			ruleConfig.Enabled = &enabled
+		} else {
+			ruleConfig.Severity = value
+		}
+		return nil
+	}
+	// the decoder of the node doesn't know the fields of the type, unknown settings are most likely typos
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if key := node.Content[i]; key.Value != "enabled" && key.Value != "severity" && key.Value != "options" {
+			return fmt.Errorf("line %d: unknown setting %q of the rule, use enabled, severity or options", key.Line, key.Value)
+		}
+	}
+	type plainRuleConfig RuleConfig
+	return node.Decode((*plainRuleConfig)(ruleConfig))
+}
+
+// A list of strings, written as a sequence or as a single string
+type stringList []string
+
+func (list *stringList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		var value string
+		if err := node.Decode(&value); err != nil {
+			return err
+		}
+		*list = stringList{value}
+		return nil
+	}
+	var values []string
+	if err := node.Decode(&values); err != nil {
+		return err
+	}
+	*list = values
+	return nil
+}
+
+// Returns the path of the configuration file in the directory or the nearest parent directory,
+// or an empty string if there's none.
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		path := filepath.Join(dir, FileName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Reads the configuration file and the files it extends.
+// Settings of the file override the extended ones, lists are replaced, options of rules are merged by their names.
+// Roots are returned relative to the working directory, every root must be an existing directory.
+func Load(path string) (*Config, error) {
+	config, err := load(path, map[string]bool{})
+	if err != nil {
+		return nil, err
+	}
+	for _, root := range config.Roots {
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			return nil, fmt.Errorf("%s: the root %s isn't a directory", path, root)
+		}
+	}
+	return config, nil
+}
+
+func load(path string, loading map[string]bool) (*Config, error) {
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+	if loading[absolutePath] {
+		return nil, fmt.Errorf("%s: the configuration extends itself", path)
+	}
+	loading[absolutePath] = true
+	defer delete(loading, absolutePath)
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := &Config{}
+	// unknown settings are most likely typos, they're errors rather than silently ignored settings
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil && err != io.EOF {
+		return nil, fmt.Errorf("%s: %s", path, err)
+	}
+	dir := filepath.Dir(path)
+	workingDir, _ := os.Getwd()
+	for index, root := range config.Roots {
+		if filepath.IsAbs(root) {
+			continue
+		}
+		root = filepath.Join(dir, filepath.FromSlash(root))
+		// findings are reported with paths relative to the working directory, like with the directory argument
+		if absoluteRoot, err := filepath.Abs(root); err == nil && len(workingDir) > 0 {
+			if relativeRoot, err := filepath.Rel(workingDir, absoluteRoot); err == nil {
+				root = relativeRoot
+			}
+		}
+		config.Roots[index] = root
+	}
+
+	merged := &Config{}
+	for _, extended := range config.Extends {
+		if !filepath.IsAbs(extended) {
+			extended = filepath.Join(dir, filepath.FromSlash(extended))
+		}
+		base, err := load(extended, loading)
+		if err != nil {
+			return nil, fmt.Errorf("%s: can't extend the configuration: %w", path, err)
+		}
+		merged.merge(base)
+	}
+	merged.merge(config)
+	merged.Extends = config.Extends
+	return merged, nil
+}
+
+// Overrides the settings with the ones set by the other configuration.
+func (config *Config) merge(other *Config) {
+	if other.Roots != nil {
+		config.Roots = other.Roots
+	}
+	if other.Include != nil {
+		config.Include = other.Include
+	}
+	if other.Exclude != nil {
+		config.Exclude = other.Exclude
+	}
+	if other.Partials != nil {
+		config.Partials = other.Partials
+	}
+	if len(other.Format) > 0 {
+		config.Format = other.Format
+	}
+	if config.Rules == nil && other.Rules != nil {
+		config.Rules = map[string]RuleConfig{}
+	}
+	for id, otherRule := range other.Rules {
+		rule := config.Rules[id]
+		if otherRule.Enabled != nil {
+			rule.Enabled = otherRule.Enabled
+		}
+		if len(otherRule.Severity) > 0 {
+			rule.Severity = otherRule.Severity
+		}
+		if otherRule.Options != nil {
+			options := map[string]interface{}{}
+			for name, value := range rule.Options {
+				options[name] = value
+			}
+			for name, value := range otherRule.Options {
+				options[name] = value
+			}
+			rule.Options = options
+		}
+		config.Rules[id] = rule
+	}
+}
+
+// Returns the enabled built-in rules and the settings of the run. Rules are enabled unless they're turned off.
+func (config *Config) Lint() ([]lint.Rule, lint.Options, error) {
+	options := lint.Options{
+		Include:     config.Include,
+		Exclude:     config.Exclude,
+		Partials:    config.Partials,
+		Severities:  map[string]string{},
+		RuleOptions: map[string]map[string]interface{}{},
+	}
+	builtinRules := lint.BuiltinRules()
+	for _, id := range sortedRuleIDs(config.Rules) {
+		if _, err := lint.SelectRules([]string{id}); err != nil {
+			return nil, options, err
+		}
+		ruleConfig := config.Rules[id]
+		if len(ruleConfig.Severity) > 0 {
+			options.Severities[id] = ruleConfig.Severity
+		}
+		if ruleConfig.Options != nil {
+			options.RuleOptions[id] = ruleConfig.Options
+		}
+	}
+
+	rules := []lint.Rule{}
+	for _, rule := range builtinRules {
+		if enabled := config.Rules[rule.ID()].Enabled; enabled == nil || *enabled {
+			rules = append(rules, rule)
+		}
+	}
+	if err := options.Validate(builtinRules); err != nil {
+		return nil, options, err
+	}
+	return rules, options, nil
+}
+
+func sortedRuleIDs(rules map[string]RuleConfig) []string {
+	ids := make([]string, 0, len(rules))
+	for id := range rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testsDir() string {
+	_, testsFile, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(testsFile), "..", "tests", "config")
+}
+
+func TestFind(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	project, _ := filepath.Abs(filepath.Join(testsDir(), "project"))
+
+	// WHEN
+	path, err := Find(filepath.Join(project, "specs", "nested"))
+
+	// THEN
+	Assert.Nil(err)
+	Assert.Equal(filepath.Join(project, FileName), path, "The configuration is discovered upward")
+
+	// GIVEN
+	empty, _ := ioutil.TempDir("", "config")
+	defer os.RemoveAll(empty)
+
+	// WHEN
+	path, err = Find(empty)
+
+	// THEN
+	Assert.Nil(err)
+	Assert.Empty(path)
+}
+
+func TestLoad(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	dir := testsDir()
+
+	// WHEN
+	config, err := Load(filepath.Join(dir, "project", FileName))
+
+	// THEN
+	Assert.Nil(err)
+	expectedRoot, _ := filepath.Abs(filepath.Join(dir, "project", "specs"))
+	Assert.Len(config.Roots, 1)
+	Assert.False(filepath.IsAbs(config.Roots[0]), "Roots are relative to the working directory")
+	root, _ := filepath.Abs(config.Roots[0])
+	Assert.Equal(expectedRoot, root, "Roots are relative to the configuration file")
+	Assert.Equal([]string{"**/*.yaml"}, []string(config.Include))
+	Assert.Equal([]string{"vendor/**"}, []string(config.Exclude), "Settings are extended")
+	Assert.Equal([]string{"**/*.fragment.yaml"}, []string(config.Partials), "Single values are lists")
+	Assert.Equal("sarif", config.Format)
+
+	Assert.Equal("warning", config.Rules["valid-example"].Severity)
+	Assert.True(*config.Rules["valid-spec"].Enabled, "Extended rules are overridden")
+	Assert.False(*config.Rules["ref-cycle"].Enabled)
+	Assert.Equal("info", config.Rules["unused-component"].Severity, "Extended settings of rules are kept")
+	Assert.Equal([]interface{}{"shared/*.yaml"}, config.Rules["unused-component"].Options["allow"], "Options are overridden")
+}
+
+func TestLoadExtendingItself(t *testing.T) {
+	Assert := assert.New(t)
+
+	// WHEN
+	_, err := Load(filepath.Join(testsDir(), "cycle", FileName))
+
+	// THEN
+	Assert.NotNil(err)
+	Assert.Contains(err.Error(), "the configuration extends itself")
+}
+
+func TestLoadWithMissingRoot(t *testing.T) {
+	Assert := assert.New(t)
+
+	// WHEN
+	_, err := Load(filepath.Join(testsDir(), "missing_root", FileName))
+
+	// THEN
+	Assert.NotNil(err)
+	Assert.Contains(err.Error(), "missing-dir isn't a directory")
+}
+
+func TestLoadWithUnknownSetting(t *testing.T) {
+	Assert := assert.New(t)
+
+	// WHEN
+	_, err := Load(filepath.Join(testsDir(), "unknown_setting", FileName))
+	_, ruleErr := Load(filepath.Join(testsDir(), "unknown_rule_setting", FileName))
+
+	// THEN
+	Assert.NotNil(err)
+	Assert.Contains(err.Error(), "line 2: field rule not found")
+	Assert.NotNil(ruleErr)
+	Assert.Contains(ruleErr.Error(), `line 3: unknown setting "severty" of the rule`)
+}
+
+func TestLoadEmpty(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	dir, err := ioutil.TempDir("", "config")
+	Assert.Nil(err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, FileName)
+	Assert.Nil(ioutil.WriteFile(path, []byte("# nothing configured yet\n"), 0644))
+
+	// WHEN
+	config, err := Load(path)
+
+	// THEN
+	Assert.Nil(err)
+	Assert.Empty(config.Roots)
+}
+
+func TestLint(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	config, _ := Load(filepath.Join(testsDir(), "project", FileName))
+
+	// WHEN
+	rules, options, err := config.Lint()
+
+	// THEN
+	Assert.Nil(err)
+	ids := []string{}
+	for _, rule := range rules {
+		ids = append(ids, rule.ID())
+	}
+	Assert.NotContains(ids, "ref-cycle", "Rules turned off aren't run")
+	Assert.Contains(ids, "valid-spec")
+	Assert.Equal(map[string]string{"valid-example": "warning", "unused-component": "info"}, options.Severities)
+	Assert.Equal("openapi", options.RuleOptions["valid-schema"]["mode"])
+	Assert.Equal([]string{"**/*.fragment.yaml"}, options.Partials)
+}
+
+func TestLintWithInvalidConfiguration(t *testing.T) {
+	Assert := assert.New(t)
+
+	t.Run("Invalid options", func(t *testing.T) {
+		// GIVEN
+		config, _ := Load(filepath.Join(testsDir(), "invalid", FileName))
+
+		// WHEN
+		_, _, err := config.Lint()
+
+		// THEN
+		Assert.EqualError(err, `rule valid-schema: unsupported mode "everything", use one of: heuristic, openapi`)
+	})
+
+	t.Run("Unknown rule", func(t *testing.T) {
+		// GIVEN
+		config := &Config{Rules: map[string]RuleConfig{"no-such-rule": {Severity: "error"}}}
+
+		// WHEN
+		_, _, err := config.Lint()
+
+		// THEN
+		Assert.EqualError(err, `unknown rule "no-such-rule"`)
+	})
+
+	t.Run("Unknown severity", func(t *testing.T) {
+		// GIVEN
+		config := &Config{Rules: map[string]RuleConfig{"valid-spec": {Severity: "fatal"}}}
+
+		// WHEN
+		_, _, err := config.Lint()
+
+		// THEN
+		Assert.EqualError(err, "rule valid-spec: unsupported severity: fatal")
+	})
+}
@@ -0,0 +1,205 @@
+// Parsing of JSON and YAML documents that keeps track of the position of every value in the source.
+// `encoding/json` throws positions away, so the document is decoded token by token
+// and the position of every value is recorded under its JSON Pointer.
+package document
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"sort"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/clearcodehq/openapi-linter/jsonpointer"
+)
+
+// Line and column (both starting from 1) of a value in the source file.
+type Position struct {
+	Line   int
+	Column int
+}
+
+// Rule reported with findings about files that can't be parsed
+const ParseErrorRule = "parse-error"
+
+// Describes the rule in reports that list rules
+const ParseErrorRuleDescription = "Files must be valid JSON or YAML documents."
+
+// Store informations about a file that can't be parsed.
+// Column is 0 if the parser doesn't report it.
+type ParseError struct {
+	Path string
+	Position
+	Err error
+}
+
+func (parseError *ParseError) Error() string {
+	return parseError.Err.Error()
+}
+
+// A parsed document with positions of all its values.
+type Document struct {
+	Path      string
+	Root      interface{}
+	positions map[string]Position
+}
+
+// Reads the file and parses its content.
+func Load(path string) (*Document, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(path, content)
+}
+
+// Parses the JSON content, or YAML content if the path has a YAML extension.
+// Objects are decoded as map[string]interface{}, arrays as []interface{}
+// and numbers as float64, the same way as json.Unmarshal does.
+func Parse(path string, content []byte) (*Document, error) {
+	if IsYAML(path) {
+		return parseYAML(path, content)
+	}
+
+	parser := parser{
+		content:   content,
+		decoder:   json.NewDecoder(bytes.NewReader(content)),
+		lines:     lineOffsets(content),
+		positions: map[string]Position{},
+	}
+
+	root, err := parser.parseValue("")
+	if err != nil {
+		return nil, parser.parseError(path, err)
+	}
+	offset := parser.nextTokenOffset()
+	if _, err := parser.decoder.Token(); err != io.EOF {
+		return nil, &ParseError{path, parser.position(offset), fmt.Errorf("invalid character after top-level value")}
+	}
+	return &Document{path, root, parser.positions}, nil
+}
+
+// Returns the position of the value under the pointer.
+// If the pointer doesn't exist in the document, the position of its nearest existing parent is returned.
+func (document *Document) Position(pointer string) Position {
+	for {
+		if position, exists := document.positions[pointer]; exists {
+			return position
+		}
+		if len(pointer) == 0 {
+			return Position{}
+		}
+		pointer = pointer[:lastSeparator(pointer)]
+	}
+}
+
+func lastSeparator(pointer string) int {
+	for i := len(pointer) - 1; i >= 0; i-- {
+		if pointer[i] == '/' {
+			return i
+		}
+	}
+	return 0
+}
+
+type parser struct {
+	content   []byte
+	decoder   *json.Decoder
+	lines     []int
+	positions map[string]Position
+}
+
+// Decodes the next value and records its position and positions of all nested values.
+func (parser *parser) parseValue(pointer string) (interface{}, error) {
+	parser.positions[pointer] = parser.position(parser.nextTokenOffset())
+
+	token, err := parser.decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch token {
+	case json.Delim('{'):
+		object := map[string]interface{}{}
+		for parser.decoder.More() {
+			keyToken, err := parser.decoder.Token()
+			if err != nil {
+				return nil, err
+			}
+			key := keyToken.(string)
+			value, err := parser.parseValue(jsonpointer.Append(pointer, key))
+			if err != nil {
+				return nil, err
+			}
+			object[key] = value
+		}
+		_, err := parser.decoder.Token()
+		return object, err
+	case json.Delim('['):
+		array := []interface{}{}
+		for parser.decoder.More() {
+			value, err := parser.parseValue(jsonpointer.Append(pointer, strconv.Itoa(len(array))))
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		_, err := parser.decoder.Token()
+		return array, err
+	}
+	return token, nil
+}
+
+// Annotates the decoder error with the position where it has occurred.
+func (parser *parser) parseError(path string, err error) *ParseError {
+	if syntaxError, isSyntaxError := err.(*json.SyntaxError); isSyntaxError {
+		// Offset is the number of bytes read, including the invalid one.
+		offset := int(syntaxError.Offset) - 1
+		if offset < 0 {
+			offset = 0
+		}
+		if syntaxError.Error() == "unexpected end of JSON input" {
+			offset = len(parser.content)
+		}
+		return &ParseError{path, parser.position(offset), err}
+	}
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		err = fmt.Errorf("unexpected end of JSON input")
+	}
+	return &ParseError{path, parser.position(len(parser.content)), err}
+}
+
+// The decoder doesn't return separators as tokens, skip them together with whitespaces.
+func (parser *parser) nextTokenOffset() int {
+	offset := int(parser.decoder.InputOffset())
+	for offset < len(parser.content) {
+		switch parser.content[offset] {
+		case ' ', '\t', '\n', '\r', ':', ',':
+			offset++
+		default:
+			return offset
+		}
+	}
+	return offset
+}
+
+// Translates the byte offset to the line and column. Columns are counted in characters.
+func (parser *parser) position(offset int) Position {
+	line := sort.Search(len(parser.lines), func(i int) bool { return parser.lines[i] > offset })
+	lineStart := parser.lines[line-1]
+	return Position{line, utf8.RuneCount(parser.content[lineStart:offset]) + 1}
+}
+
+// Returns offsets of the first byte of every line.
+func lineOffsets(content []byte) []int {
+	offsets := []int{0}
+	for offset, char := range content {
+		if char == '\n' {
+			offsets = append(offsets, offset+1)
+		}
+	}
+	return offsets
+}
@@ -0,0 +1,199 @@
+package document_test
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	Assert := assert.New(t)
+
+	t.Run("Decode values the same way as json.Unmarshal", func(t *testing.T) {
+		// WHEN
+		doc, err := document.Parse("spec.json", []byte(`{"a": [1, "b", true, null], "c": {}}`))
+
+		// THEN
+		Assert.Nil(err)
+		Assert.Equal(map[string]interface{}{
+			"a": []interface{}{float64(1), "b", true, nil},
+			"c": map[string]interface{}{},
+		}, doc.Root)
+	})
+
+	t.Run("Invalid JSON", func(t *testing.T) {
+		_, err := document.Parse("spec.json", []byte(`{"a": }`))
+		Assert.NotNil(err)
+	})
+
+	t.Run("Content after the top-level value", func(t *testing.T) {
+		_, err := document.Parse("spec.json", []byte(`{} {}`))
+		Assert.NotNil(err)
+	})
+}
+
+func TestPosition(t *testing.T) {
+	Assert := assert.New(t)
+	doc, err := document.Parse("spec.json", []byte(`{
+  "definitions": {
+    "user": {"type": "object"},
+    "a/b": [1,
+      "żółw", 3]
+  }
+}`))
+	Assert.Nil(err)
+
+	t.Run("Root object", func(t *testing.T) {
+		Assert.Equal(document.Position{Line: 1, Column: 1}, doc.Position(""))
+	})
+
+	t.Run("Nested values", func(t *testing.T) {
+		Assert.Equal(document.Position{Line: 2, Column: 18}, doc.Position("/definitions"))
+		Assert.Equal(document.Position{Line: 3, Column: 13}, doc.Position("/definitions/user"))
+		Assert.Equal(document.Position{Line: 3, Column: 22}, doc.Position("/definitions/user/type"))
+	})
+
+	t.Run("Escaped keys and array items", func(t *testing.T) {
+		Assert.Equal(document.Position{Line: 4, Column: 12}, doc.Position("/definitions/a~1b"))
+		Assert.Equal(document.Position{Line: 4, Column: 13}, doc.Position("/definitions/a~1b/0"))
+		Assert.Equal(document.Position{Line: 5, Column: 7}, doc.Position("/definitions/a~1b/1"))
+		Assert.Equal(document.Position{Line: 5, Column: 15}, doc.Position("/definitions/a~1b/2"))
+	})
+
+	t.Run("Fallback to the nearest parent", func(t *testing.T) {
+		Assert.Equal(document.Position{Line: 3, Column: 13}, doc.Position("/definitions/user/properties/id"))
+	})
+}
+
+func TestParseYAML(t *testing.T) {
+	Assert := assert.New(t)
+
+	t.Run("Decode values the same way as JSON values", func(t *testing.T) {
+		// WHEN
+		doc, err := document.Parse("spec.yaml", []byte(`
+responses:
+  200:
+    description: OK
+    count: 1
+    ratio: 0.5
+    nullable: true
+    missing: null
+    date: 2020-01-01
+  tags: [a, b]
+`))
+
+		// THEN
+		Assert.Nil(err)
+		Assert.Equal(map[string]interface{}{
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{
+					"description": "OK",
+					"count":       float64(1),
+					"ratio":       0.5,
+					"nullable":    true,
+					"missing":     nil,
+					"date":        "2020-01-01",
+				},
+				"tags": []interface{}{"a", "b"},
+			},
+		}, doc.Root)
+	})
+
+	t.Run("Anchors and merge keys", func(t *testing.T) {
+		// WHEN
+		doc, err := document.Parse("spec.yml", []byte(`
+base: &base
+  type: object
+  title: Base
+user:
+  <<: *base
+  title: User
+copy: *base
+`))
+
+		// THEN
+		Assert.Nil(err)
+		Assert.Equal(map[string]interface{}{"type": "object", "title": "User"}, doc.Root.(map[string]interface{})["user"])
+		Assert.Equal(map[string]interface{}{"type": "object", "title": "Base"}, doc.Root.(map[string]interface{})["copy"])
+	})
+
+	t.Run("Positions point to the YAML source", func(t *testing.T) {
+		// WHEN
+		doc, err := document.Parse("spec.yaml", []byte(`paths:
+  /users:
+    get:
+      summary: Users
+`))
+
+		// THEN
+		Assert.Nil(err)
+		Assert.Equal(document.Position{Line: 2, Column: 3}, doc.Position("/paths/~1users"))
+		Assert.Equal(document.Position{Line: 4, Column: 16}, doc.Position("/paths/~1users/get/summary"))
+	})
+
+	t.Run("Invalid YAML", func(t *testing.T) {
+		_, err := document.Parse("spec.yaml", []byte("a: [b"))
+		Assert.NotNil(err)
+	})
+}
+
+func TestParseError(t *testing.T) {
+	Assert := assert.New(t)
+
+	testCases := []struct {
+		name     string
+		path     string
+		content  string
+		position document.Position
+	}{
+		{"Invalid character", "spec.json", "{\n  \"a\": }", document.Position{Line: 2, Column: 8}},
+		{"Missing comma", "spec.json", "{\n  \"a\": 1\n  \"b\": 2\n}", document.Position{Line: 3, Column: 3}},
+		{"Unexpected end of input", "spec.json", "{\n  \"a\": [1", document.Position{Line: 2, Column: 10}},
+		{"Empty file", "spec.json", "", document.Position{Line: 1, Column: 1}},
+		{"Content after the top-level value", "spec.json", "{}\n{}", document.Position{Line: 2, Column: 1}},
+		{"Invalid YAML", "spec.yaml", "a: b\nc: d: e\n", document.Position{Line: 2}},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// WHEN
+			_, err := document.Parse(testCase.path, []byte(testCase.content))
+
+			// THEN
+			parseError, isParseError := err.(*document.ParseError)
+			Assert.True(isParseError)
+			Assert.Equal(testCase.path, parseError.Path)
+			Assert.Equal(testCase.position, parseError.Position)
+		})
+	}
+}
+
+func TestParseAliasBomb(t *testing.T) {
+	Assert := assert.New(t)
+	_, testsFile, _, _ := runtime.Caller(0)
+	dir := filepath.Join(filepath.Dir(testsFile), "..", "tests", "document", "alias_bomb")
+
+	for _, file := range []string{"laughs.yaml", "merge_keys.yaml"} {
+		t.Run(file, func(t *testing.T) {
+			// WHEN
+			_, err := document.Load(filepath.Join(dir, file))
+
+			// THEN
+			parseError, isParseError := err.(*document.ParseError)
+			Assert.True(isParseError, "Nested aliases aren't expanded without a limit")
+			Assert.Contains(parseError.Error(), "document contains excessive aliasing")
+			Assert.NotZero(parseError.Line)
+		})
+	}
+
+	t.Run("Aliases within the limit", func(t *testing.T) {
+		// WHEN
+		parsed, err := document.Parse("spec.yaml", []byte("a: &a [1, 2]\nb: [*a, *a, *a]\nc: {<<: {d: 1}}\n"))
+
+		// THEN
+		Assert.Nil(err)
+		Assert.Len(parsed.Root.(map[string]interface{})["b"], 3)
+	})
+}
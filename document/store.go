@@ -0,0 +1,198 @@
+package document
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/clearcodehq/openapi-linter/jsonpointer"
+)
+
+// Keeps documents in memory by their canonical URI, so every file is read and parsed once
+// and references are resolved without touching the file system again.
+// Implements http.FileSystem for gojsonschema reference loaders.
+// It's safe for concurrent use.
+type Store struct {
+	mutex     sync.Mutex
+	documents map[string]*Document
+	errors    map[string]error
+}
+
+func NewStore() *Store {
+	return &Store{documents: map[string]*Document{}, errors: map[string]error{}}
+}
+
+// Returns the canonical URI of the file path (or the `file://` URI), without the fragment.
+// e.g. specs/user.yaml -> file:///home/specs/user.yaml
+func URI(path string) string {
+	if strings.HasPrefix(path, "file://") {
+		if fileURL, err := url.Parse(path); err == nil {
+			path = fileURL.Path
+		}
+	}
+	if absolutePath, err := filepath.Abs(path); err == nil {
+		path = absolutePath
+	}
+	path = filepath.ToSlash(path)
+	// Windows, e.g. file:///C:/specs/user.yaml
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	fileURL := url.URL{Scheme: "file", Path: path}
+	return fileURL.String()
+}
+
+// Returns the path of the file identified by the canonical URI.
+func FilePath(uri string) string {
+	fileURL, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+	path := fileURL.Path
+	// Windows, e.g. /C:/specs/user.yaml
+	if len(path) > 2 && path[2] == ':' {
+		path = path[1:]
+	}
+	return filepath.FromSlash(path)
+}
+
+// Adds the already parsed document, so it isn't read from the file again.
+func (store *Store) Add(document *Document) {
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	store.documents[URI(document.Path)] = document
+}
+
+// Returns the document of the file path (or the `file://` URI), the file is read and parsed only the first time.
+// Errors are remembered too, syntax errors are returned as *ParseError.
+func (store *Store) Load(path string) (*Document, error) {
+	uri := URI(path)
+
+	store.mutex.Lock()
+	defer store.mutex.Unlock()
+	if document, loaded := store.documents[uri]; loaded {
+		return document, nil
+	}
+	if err, failed := store.errors[uri]; failed {
+		return nil, err
+	}
+
+	document, err := Load(FilePath(uri))
+	if err != nil {
+		store.errors[uri] = err
+		return nil, err
+	}
+	store.documents[uri] = document
+	return document, nil
+}
+
+// Resolves the reference (e.g. `user.yaml#/definitions/User` or `#/definitions/User`) relative to the base
+// file path (or URI) and returns the referenced value and the canonical URI of the document that holds it.
+// The fragment is a JSON Pointer, errors name the reference token that couldn't be found.
+func (store *Store) Resolve(base string, ref string) (interface{}, string, error) {
+	uri, pointer, err := Locate(base, ref)
+	if err != nil {
+		return nil, uri, err
+	}
+	value, err := store.Get(uri, pointer)
+	return value, uri, err
+}
+
+// Returns the value under the JSON Pointer of the document identified by the file path (or URI).
+func (store *Store) Get(path string, pointer string) (interface{}, error) {
+	document, err := store.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	value, err := jsonpointer.Get(document.Root, pointer)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", document.Path, err)
+	}
+	return value, nil
+}
+
+// Returns the canonical URI of the document and the JSON Pointer referenced by the reference,
+// relative to the base file path (or URI). Nothing is loaded.
+// e.g. Locate("specs/spec.yaml", "user.yaml#/definitions/User") -> file:///home/specs/user.yaml, /definitions/User
+func Locate(base string, ref string) (string, string, error) {
+	baseURL, err := url.Parse(URI(base))
+	if err != nil {
+		return "", "", err
+	}
+	refURL, err := url.Parse(ref)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid reference %q: %s", ref, err)
+	}
+	if refURL.Scheme != "" && refURL.Scheme != "file" {
+		return "", "", fmt.Errorf("can't resolve the reference %q, only local files are supported", ref)
+	}
+
+	resolved := baseURL.ResolveReference(refURL)
+	// the fragment is already percent-decoded
+	pointer := resolved.Fragment
+	resolved.Fragment = ""
+	if len(pointer) > 0 && !strings.HasPrefix(pointer, "/") {
+		// e.g. user.json#definitions/User
+		pointer = "/" + pointer
+	}
+	return URI(resolved.String()), pointer, nil
+}
+
+// Serves the document as JSON, YAML documents are converted to JSON.
+func (store *Store) Open(name string) (http.File, error) {
+	return store.open(name, nil)
+}
+
+// Returns a view of the store serving the documents with their roots converted by the function, e.g. to translate keywords
+// gojsonschema doesn't know. The function gets the root of the document and must not modify it.
+func (store *Store) Converted(convert func(root interface{}) interface{}) http.FileSystem {
+	return &convertedStore{store, convert}
+}
+
+type convertedStore struct {
+	store   *Store
+	convert func(root interface{}) interface{}
+}
+
+func (converted *convertedStore) Open(name string) (http.File, error) {
+	return converted.store.open(name, converted.convert)
+}
+
+func (store *Store) open(name string, convert func(root interface{}) interface{}) (http.File, error) {
+	document, err := store.Load(name)
+	if err != nil {
+		return nil, err
+	}
+	root := document.Root
+	if convert != nil {
+		root = convert(root)
+	}
+	content, err := json.Marshal(root)
+	if err != nil {
+		return nil, err
+	}
+	return &file{bytes.NewReader(content), document.Path}, nil
+}
+
+type file struct {
+	*bytes.Reader
+	name string
+}
+
+func (file *file) Close() error {
+	return nil
+}
+
+func (file *file) Readdir(count int) ([]os.FileInfo, error) {
+	return nil, nil
+}
+
+func (file *file) Stat() (os.FileInfo, error) {
+	return os.Stat(file.name)
+}
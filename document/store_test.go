@@ -0,0 +1,92 @@
+package document_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStore(t *testing.T) {
+	Assert := assert.New(t)
+	_, testsFile, _, _ := runtime.Caller(0)
+	dir := filepath.Join(filepath.Dir(testsFile), "..", "tests", "document", "store")
+	specPath := filepath.Join(dir, "spec.yaml")
+	userPath := filepath.Join(dir, "nested", "user schema.json")
+
+	t.Run("Documents are loaded once by their canonical URI", func(t *testing.T) {
+		// GIVEN
+		store := document.NewStore()
+
+		// WHEN
+		first, err := store.Load(specPath)
+		second, _ := store.Load(filepath.Join(dir, "nested", "..", "spec.yaml"))
+		third, _ := store.Load(document.URI(specPath))
+
+		// THEN
+		Assert.Nil(err)
+		Assert.Same(first, second)
+		Assert.Same(first, third)
+	})
+
+	t.Run("Added documents aren't read from the file", func(t *testing.T) {
+		// GIVEN
+		store := document.NewStore()
+		added, _ := document.Parse(filepath.Join(dir, "missing.json"), []byte(`{"a": 1}`))
+
+		// WHEN
+		store.Add(added)
+		loaded, err := store.Load(filepath.Join(dir, "missing.json"))
+
+		// THEN
+		Assert.Nil(err)
+		Assert.Same(added, loaded)
+	})
+
+	t.Run("Resolve references relative to the base document", func(t *testing.T) {
+		// GIVEN
+		store := document.NewStore()
+
+		// WHEN
+		user, userURI, err := store.Resolve(specPath, "nested/user%20schema.json#/definitions/User")
+		pet, petURI, petErr := store.Resolve(userURI, "../spec.yaml#/components/schemas/Pet")
+		escaped, _, escapedErr := store.Resolve(userURI, "#/definitions/a~1b%20c")
+
+		// THEN
+		Assert.Nil(err)
+		Assert.Equal(document.URI(userPath), userURI)
+		Assert.Equal("object", user.(map[string]interface{})["type"])
+		Assert.Nil(petErr)
+		Assert.Equal(document.URI(specPath), petURI)
+		Assert.Equal(map[string]interface{}{"type": "object"}, pet)
+		Assert.Nil(escapedErr)
+		Assert.Equal(map[string]interface{}{"type": "string"}, escaped)
+	})
+
+	t.Run("Errors name the missing segment", func(t *testing.T) {
+		// WHEN
+		_, _, err := document.NewStore().Resolve(specPath, "#/components/schemas/Dog")
+
+		// THEN
+		Assert.EqualError(err, specPath+`: can't resolve the JSON pointer "/components/schemas/Dog" at "/components/schemas": "Dog" not found`)
+	})
+
+	t.Run("Remote references aren't resolved", func(t *testing.T) {
+		_, _, err := document.NewStore().Resolve(specPath, "https://example.com/user.json")
+		Assert.NotNil(err)
+	})
+
+	t.Run("YAML documents are served as JSON", func(t *testing.T) {
+		// WHEN
+		file, err := document.NewStore().Open(specPath)
+
+		// THEN
+		Assert.Nil(err)
+		content, _ := ioutil.ReadAll(file)
+		Assert.True(json.Valid(content), string(content))
+	})
+}
@@ -0,0 +1,220 @@
+package document
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/clearcodehq/openapi-linter/jsonpointer"
+	"gopkg.in/yaml.v3"
+)
+
+// Extensions of files that can be parsed.
+var Extensions = []string{".json", ".yaml", ".yml"}
+
+// Determines if the file has one of the supported extensions.
+func IsSupported(path string) bool {
+	extension := strings.ToLower(filepath.Ext(path))
+	for _, supported := range Extensions {
+		if extension == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// Determines if the file should be parsed as YAML.
+func IsYAML(path string) bool {
+	extension := strings.ToLower(filepath.Ext(path))
+	return extension == ".yaml" || extension == ".yml"
+}
+
+// Parses the YAML content. Values are decoded to the same types as JSON values,
+// so the rest of the linter doesn't have to know the format of the source file.
+// Only the first document of a multi-document stream is parsed.
+func parseYAML(path string, content []byte) (*Document, error) {
+	root := yaml.Node{}
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return nil, yamlParseError(path, err)
+	}
+
+	converter := yamlConverter{positions: map[string]Position{}}
+	if len(root.Content) == 0 {
+		return &Document{path, nil, converter.positions}, nil
+	}
+
+	value, err := converter.convert("", root.Content[0], 0)
+	if err != nil {
+		return nil, yamlParseError(path, err)
+	}
+	return &Document{path, value, converter.positions}, nil
+}
+
+var yamlErrorLine = regexp.MustCompile(`line (\d+)`)
+
+// yaml.v3 reports only the line of the error as a part of the message.
+func yamlParseError(path string, err error) *ParseError {
+	position := Position{}
+	if match := yamlErrorLine.FindStringSubmatch(err.Error()); match != nil {
+		position.Line, _ = strconv.Atoi(match[1])
+	}
+	return &ParseError{path, position, err}
+}
+
+// Aliases can point to their parents, limit the depth to not fall into an infinite recursion.
+const maxYAMLDepth = 1000
+
+// Documents with more converted nodes get a lower share of nodes converted by expanding aliases, see allowedAliasRatio.
+const (
+	aliasRatioRangeLow  = 400000
+	aliasRatioRangeHigh = 4000000
+)
+
+// Returns the share of nodes that may come from alias expansion, like yaml.v3 does when decoding to values.
+// Nested aliases expand exponentially (the "billion laughs" attack), the limit keeps the converted document
+// in the order of a few hundred thousand nodes.
+func allowedAliasRatio(convertCount int) float64 {
+	switch {
+	case convertCount <= aliasRatioRangeLow:
+		return 0.99
+	case convertCount >= aliasRatioRangeHigh:
+		return 0.10
+	default:
+		return 0.99 - 0.89*(float64(convertCount-aliasRatioRangeLow)/float64(aliasRatioRangeHigh-aliasRatioRangeLow))
+	}
+}
+
+type yamlConverter struct {
+	positions map[string]Position
+	// Number of converted nodes, number of nodes converted by expanding aliases and the number of aliases being expanded
+	convertCount int
+	aliasCount   int
+	aliasDepth   int
+}
+
+// Converts the YAML node to a JSON-like value and records positions of all nested values.
+func (converter *yamlConverter) convert(pointer string, node *yaml.Node, depth int) (interface{}, error) {
+	if depth > maxYAMLDepth {
+		return nil, fmt.Errorf("yaml: line %d: aliases are nested too deeply", node.Line)
+	}
+	converter.convertCount++
+	if converter.aliasDepth > 0 {
+		converter.aliasCount++
+	}
+	if converter.aliasCount > 100 && converter.convertCount > 1000 &&
+		float64(converter.aliasCount)/float64(converter.convertCount) > allowedAliasRatio(converter.convertCount) {
+		return nil, fmt.Errorf("yaml: line %d: document contains excessive aliasing", node.Line)
+	}
+	if _, exists := converter.positions[pointer]; !exists {
+		converter.positions[pointer] = Position{node.Line, node.Column}
+	}
+
+	switch node.Kind {
+	case yaml.AliasNode:
+		converter.aliasDepth++
+		value, err := converter.convert(pointer, node.Alias, depth+1)
+		converter.aliasDepth--
+		return value, err
+	case yaml.MappingNode:
+		object := map[string]interface{}{}
+		if err := converter.convertMapping(pointer, node, object, depth); err != nil {
+			return nil, err
+		}
+		return object, nil
+	case yaml.SequenceNode:
+		array := []interface{}{}
+		for index, item := range node.Content {
+			value, err := converter.convert(jsonpointer.Append(pointer, strconv.Itoa(index)), item, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			array = append(array, value)
+		}
+		return array, nil
+	}
+	return convertScalar(node)
+}
+
+// Converts mapping keys and values. Supports merge keys (`<<`), explicit keys take precedence over merged ones.
+func (converter *yamlConverter) convertMapping(pointer string, node *yaml.Node, object map[string]interface{}, depth int) error {
+	merged := []*yaml.Node{}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.ShortTag() == "!!merge" {
+			merged = append(merged, value)
+			continue
+		}
+
+		childPointer := jsonpointer.Append(pointer, key.Value)
+		if isBlockCollection(value) {
+			// Block collections start in the next line, point to their key instead.
+			converter.positions[childPointer] = Position{key.Line, key.Column}
+		}
+		converted, err := converter.convert(childPointer, value, depth+1)
+		if err != nil {
+			return err
+		}
+		object[key.Value] = converted
+	}
+
+	for _, mergedNode := range merged {
+		isAlias := mergedNode.Kind == yaml.AliasNode
+		if isAlias {
+			mergedNode = mergedNode.Alias
+		}
+		sources := []*yaml.Node{mergedNode}
+		if mergedNode.Kind == yaml.SequenceNode {
+			sources = mergedNode.Content
+		}
+		for _, source := range sources {
+			isSourceAlias := isAlias || source.Kind == yaml.AliasNode
+			if source.Kind == yaml.AliasNode {
+				source = source.Alias
+			}
+			if source.Kind != yaml.MappingNode {
+				return fmt.Errorf("yaml: line %d: map merge requires map or sequence of maps as the value", source.Line)
+			}
+			mergedObject := map[string]interface{}{}
+			// merged aliases are expanded like other aliases
+			if isSourceAlias {
+				converter.aliasDepth++
+			}
+			err := converter.convertMapping(pointer, source, mergedObject, depth+1)
+			if isSourceAlias {
+				converter.aliasDepth--
+			}
+			if err != nil {
+				return err
+			}
+			for key, value := range mergedObject {
+				if _, exists := object[key]; !exists {
+					object[key] = value
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func isBlockCollection(node *yaml.Node) bool {
+	return (node.Kind == yaml.MappingNode || node.Kind == yaml.SequenceNode) && node.Style&yaml.FlowStyle == 0
+}
+
+// Decodes scalars to the types used by encoding/json, all numbers are float64.
+func convertScalar(node *yaml.Node) (interface{}, error) {
+	switch node.ShortTag() {
+	case "!!null":
+		return nil, nil
+	case "!!bool":
+		value := false
+		err := node.Decode(&value)
+		return value, err
+	case "!!int", "!!float":
+		value := float64(0)
+		err := node.Decode(&value)
+		return value, err
+	}
+	return node.Value, nil
+}
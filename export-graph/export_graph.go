@@ -0,0 +1,217 @@
+package export_graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/references"
+	"github.com/clearcodehq/openapi-linter/validate"
+)
+
+// Granularity of the nodes of the dependency graph
+type Level string
+
+const (
+	// Components (e.g. `#/components/schemas/User`), path items (e.g. `#/paths/~1users`) and documents
+	LevelComponent Level = "component"
+	// Documents only
+	LevelFile Level = "file"
+)
+
+// Supported output formats
+const (
+	FormatDOT  = "dot"
+	FormatJSON = "json"
+)
+
+// The `$ref` dependencies between the documents of a directory.
+// Nodes are named relative to the directory, e.g. `openapi.yaml#/components/schemas/User` or `schemas/user.yaml`.
+type DependencyGraph struct {
+	// Dependencies of every node, both sorted by name
+	Dependencies map[string][]string
+}
+
+// Returns the names of all nodes, sorted.
+func (graph DependencyGraph) Nodes() []string {
+	nodes := make([]string, 0, len(graph.Dependencies))
+	for node := range graph.Dependencies {
+		nodes = append(nodes, node)
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// Returns an error if the level or the format isn't supported.
+func ValidateOptions(level string, format string) error {
+	if level != string(LevelComponent) && level != string(LevelFile) {
+		return fmt.Errorf("unsupported level %q, use %s or %s", level, LevelComponent, LevelFile)
+	}
+	if format != FormatDOT && format != FormatJSON {
+		return fmt.Errorf("unsupported format %q, use %s or %s", format, FormatDOT, FormatJSON)
+	}
+	return nil
+}
+
+// Builds the dependency graph of all JSON and YAML documents within the directory.
+// The root (e.g. `openapi.yaml` or `openapi.yaml#/components/schemas/User`, relative to the directory) scopes the graph
+// to the nodes it reaches, the whole directory is exported if it's empty.
+// Every `$ref` (and discriminator mapping) is an edge from the node holding it to the node holding its target.
+// Files that can't be read or parsed are returned as validation errors and left out of the graph.
+func BuildDependencyGraph(dir string, root string, level Level) (DependencyGraph, []validate.ValidationError, error) {
+	files, err := validate.FindJsonFiles(dir)
+	if err != nil {
+		return DependencyGraph{}, nil, err
+	}
+
+	validationErrors := []validate.ValidationError{}
+	store := document.NewStore()
+	uris := []string{}
+	for _, file := range files {
+		if _, fileErr := store.Load(file); fileErr != nil {
+			validationErrors = append(validationErrors, validate.FileError(file, fileErr))
+			continue
+		}
+		uris = append(uris, document.URI(file))
+	}
+	refGraph := references.BuildGraph(store, uris)
+	absoluteDir, err := filepath.Abs(dir)
+	if err != nil {
+		return DependencyGraph{}, validationErrors, err
+	}
+
+	owner := func(node references.Node) references.Node {
+		if level == LevelFile {
+			return references.Node{URI: node.URI}
+		}
+		return references.Owner(store, node)
+	}
+	name := func(node references.Node) string {
+		path := document.FilePath(node.URI)
+		if relativePath, err := filepath.Rel(absoluteDir, path); err == nil {
+			path = relativePath
+		}
+		if len(node.Pointer) == 0 {
+			return filepath.ToSlash(path)
+		}
+		return filepath.ToSlash(path) + "#" + node.Pointer
+	}
+
+	dependencies := map[string]map[string]bool{}
+	addNode := func(node references.Node) {
+		if dependencies[name(node)] == nil {
+			dependencies[name(node)] = map[string]bool{}
+		}
+	}
+	addEdge := func(edge references.Edge) {
+		from, to := owner(edge.From), owner(edge.To)
+		addNode(from)
+		addNode(to)
+		// documents referencing themselves aren't dependencies
+		if level != LevelFile || from != to {
+			dependencies[name(from)][name(to)] = true
+		}
+	}
+
+	if len(root) == 0 {
+		for _, uri := range refGraph.Documents {
+			addNode(references.Node{URI: uri})
+			if level == LevelComponent {
+				for _, component := range references.Components(store, uri) {
+					addNode(component)
+				}
+			}
+		}
+		for _, edge := range refGraph.Edges {
+			addEdge(edge)
+		}
+	} else {
+		rootNode, err := locateRoot(store, dir, root)
+		if err != nil {
+			return DependencyGraph{}, validationErrors, err
+		}
+		addNode(owner(rootNode))
+		for _, node := range refGraph.Reachable([]references.Node{rootNode}) {
+			for _, edge := range refGraph.EdgesWithin(node) {
+				addEdge(edge)
+			}
+		}
+	}
+
+	graph := DependencyGraph{Dependencies: map[string][]string{}}
+	for node, targets := range dependencies {
+		graph.Dependencies[node] = []string{}
+		for target := range targets {
+			graph.Dependencies[node] = append(graph.Dependencies[node], target)
+		}
+		sort.Strings(graph.Dependencies[node])
+	}
+	return graph, validationErrors, nil
+}
+
+// Returns the node of the root document or component, relative to the directory.
+func locateRoot(store *document.Store, dir string, root string) (references.Node, error) {
+	parts := strings.SplitN(root, "#", 2)
+	uri := document.URI(filepath.Join(dir, filepath.FromSlash(parts[0])))
+	pointer := ""
+	if len(parts) == 2 {
+		var err error
+		if _, pointer, err = document.Locate(uri, "#"+parts[1]); err != nil {
+			return references.Node{}, err
+		}
+	}
+	if _, err := store.Get(uri, pointer); err != nil {
+		return references.Node{}, fmt.Errorf("can't find the root %q: %w", root, err)
+	}
+	return references.Node{URI: uri, Pointer: pointer}, nil
+}
+
+// Writes the graph as Graphviz DOT, nodes of components are grouped by their document.
+func WriteDOT(writer io.Writer, graph DependencyGraph) error {
+	lines := []string{"digraph refs {", "  rankdir=LR;", "  node [shape=box];"}
+	clusters := map[string][]string{}
+	files := []string{}
+	for _, node := range graph.Nodes() {
+		file := strings.SplitN(node, "#", 2)[0]
+		if _, found := clusters[file]; !found {
+			files = append(files, file)
+		}
+		clusters[file] = append(clusters[file], node)
+	}
+	for index, file := range files {
+		if len(clusters[file]) == 1 && clusters[file][0] == file {
+			lines = append(lines, fmt.Sprintf("  %s;", strconv.Quote(file)))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("  subgraph %s {", strconv.Quote("cluster_"+strconv.Itoa(index))), fmt.Sprintf("    label=%s;", strconv.Quote(file)))
+		for _, node := range clusters[file] {
+			label := file
+			if parts := strings.SplitN(node, "#", 2); len(parts) == 2 {
+				label = "#" + parts[1]
+			}
+			lines = append(lines, fmt.Sprintf("    %s [label=%s];", strconv.Quote(node), strconv.Quote(label)))
+		}
+		lines = append(lines, "  }")
+	}
+	for _, node := range graph.Nodes() {
+		for _, target := range graph.Dependencies[node] {
+			lines = append(lines, fmt.Sprintf("  %s -> %s;", strconv.Quote(node), strconv.Quote(target)))
+		}
+	}
+	lines = append(lines, "}")
+	_, err := io.WriteString(writer, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// Writes the graph as a JSON object of adjacency lists: the dependencies of every node by its name.
+func WriteJSON(writer io.Writer, graph DependencyGraph) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	return encoder.Encode(graph.Dependencies)
+}
@@ -0,0 +1,134 @@
+package export_graph
+
+import (
+	"bytes"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testsDir() string {
+	_, testsFile, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(testsFile), "..", "tests", "export_graph", "build_dependency_graph")
+}
+
+func TestBuildDependencyGraph(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	dir := testsDir()
+
+	// WHEN
+	graph, validationErrors, err := BuildDependencyGraph(dir, "", LevelComponent)
+
+	// THEN
+	Assert.Nil(err)
+	Assert.Empty(validationErrors)
+	Assert.Equal(map[string][]string{
+		"openapi.yaml":                             {},
+		"openapi.yaml#/paths/~1users":              {"openapi.yaml#/components/responses/Users"},
+		"openapi.yaml#/components/responses/Users": {"schemas/user.yaml#/User"},
+		"openapi.yaml#/components/schemas/Error":   {},
+		"schemas/user.yaml":                        {},
+		"schemas/user.yaml#/Address":               {},
+		"schemas/user.yaml#/User":                  {"schemas/user.yaml#/Address", "schemas/user.yaml#/User"},
+	}, graph.Dependencies)
+}
+
+func TestBuildDependencyGraphSkipsUnparsableFiles(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	dir := filepath.Join(testsDir(), "..", "unparsable_file")
+
+	// WHEN
+	graph, validationErrors, err := BuildDependencyGraph(dir, "", LevelFile)
+
+	// THEN
+	Assert.Nil(err)
+	Assert.Equal(map[string][]string{"openapi.yaml": {}}, graph.Dependencies)
+	Assert.Len(validationErrors, 1)
+	Assert.Equal(filepath.Join(dir, "broken.yaml")+":2", validationErrors[0].Finding().Location(), "Parse errors are reported with their position")
+}
+
+func TestBuildDependencyGraphOfFiles(t *testing.T) {
+	Assert := assert.New(t)
+
+	// WHEN
+	graph, _, err := BuildDependencyGraph(testsDir(), "", LevelFile)
+
+	// THEN
+	Assert.Nil(err)
+	Assert.Equal(map[string][]string{
+		"openapi.yaml":      {"schemas/user.yaml"},
+		"schemas/user.yaml": {},
+	}, graph.Dependencies, "References within a document aren't dependencies")
+}
+
+func TestBuildDependencyGraphFromRoot(t *testing.T) {
+	Assert := assert.New(t)
+
+	// WHEN
+	graph, _, err := BuildDependencyGraph(testsDir(), "schemas/user.yaml#/User/properties/address", LevelComponent)
+
+	// THEN
+	Assert.Nil(err)
+	Assert.Equal(map[string][]string{
+		"schemas/user.yaml#/User":    {"schemas/user.yaml#/Address"},
+		"schemas/user.yaml#/Address": {},
+	}, graph.Dependencies)
+
+	// WHEN
+	_, _, err = BuildDependencyGraph(testsDir(), "openapi.yaml#/components/schemas/Missing", LevelComponent)
+
+	// THEN
+	Assert.NotNil(err)
+}
+
+func TestWriteDOT(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	graph := DependencyGraph{Dependencies: map[string][]string{
+		"openapi.yaml#/paths/~1users": {"schemas/user.yaml"},
+		"schemas/user.yaml":           {},
+	}}
+	output := bytes.Buffer{}
+
+	// WHEN
+	err := WriteDOT(&output, graph)
+
+	// THEN
+	Assert.Nil(err)
+	Assert.Equal(`digraph refs {
+  rankdir=LR;
+  node [shape=box];
+  subgraph "cluster_0" {
+    label="openapi.yaml";
+    "openapi.yaml#/paths/~1users" [label="#/paths/~1users"];
+  }
+  "schemas/user.yaml";
+  "openapi.yaml#/paths/~1users" -> "schemas/user.yaml";
+}
+`, output.String())
+}
+
+func TestWriteJSON(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	graph := DependencyGraph{Dependencies: map[string][]string{
+		"openapi.yaml":      {"schemas/user.yaml"},
+		"schemas/user.yaml": {},
+	}}
+	output := bytes.Buffer{}
+
+	// WHEN
+	err := WriteJSON(&output, graph)
+
+	// THEN
+	Assert.Nil(err)
+	Assert.JSONEq(`{"openapi.yaml": ["schemas/user.yaml"], "schemas/user.yaml": []}`, output.String())
+}
@@ -1,13 +1,13 @@
 module github.com/clearcodehq/openapi-linter
 
 require (
-	github.com/PaesslerAG/jsonpath v0.1.1
 	github.com/bmatcuk/doublestar v1.2.2
 	github.com/inconshreveable/mousetrap v1.0.0 // indirect
 	github.com/spf13/cobra v0.0.3
 	github.com/spf13/pflag v1.0.5 // indirect
 	github.com/stretchr/testify v1.4.0
 	github.com/xeipuuv/gojsonschema v1.2.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 go 1.13
@@ -0,0 +1,152 @@
+// Helpers to work with JSON Pointers (RFC 6901).
+package jsonpointer
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var tokenEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// Escapes a single reference token, `~` becomes `~0` and `/` becomes `~1`.
+func Escape(token string) string {
+	return tokenEscaper.Replace(token)
+}
+
+// Appends a reference token to the pointer.
+// e.g. Append("/definitions", "a/b") -> /definitions/a~1b
+func Append(pointer string, token string) string {
+	return pointer + "/" + Escape(token)
+}
+
+// Unescapes a single reference token, `~1` becomes `/` and `~0` becomes `~`.
+// Returns an error if `~` isn't followed by `0` or `1`.
+func Unescape(token string) (string, error) {
+	if !strings.Contains(token, "~") {
+		return token, nil
+	}
+	var unescaped strings.Builder
+	for i := 0; i < len(token); i++ {
+		if token[i] != '~' {
+			unescaped.WriteByte(token[i])
+			continue
+		}
+		if i+1 == len(token) || (token[i+1] != '0' && token[i+1] != '1') {
+			return "", fmt.Errorf("invalid escape sequence in the reference token %q, `~` must be followed by `0` or `1`", token)
+		}
+		if token[i+1] == '0' {
+			unescaped.WriteByte('~')
+		} else {
+			unescaped.WriteByte('/')
+		}
+		i++
+	}
+	return unescaped.String(), nil
+}
+
+// Splits the pointer into unescaped reference tokens.
+// Pointers starting with `#` are URI fragments (e.g. the fragment of a `$ref`), they're percent-decoded first.
+// e.g. #/paths/~1users%7Bid%7D -> [paths /users{id}]
+func Parse(pointer string) ([]string, error) {
+	if strings.HasPrefix(pointer, "#") {
+		decoded, err := url.PathUnescape(pointer[1:])
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON pointer %q: %s", pointer, err)
+		}
+		pointer = decoded
+	}
+	if len(pointer) == 0 {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q, it must be empty or start with `/`", pointer)
+	}
+
+	tokens := strings.Split(pointer[1:], "/")
+	for index, token := range tokens {
+		unescaped, err := Unescape(token)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON pointer %q: %s", pointer, err)
+		}
+		tokens[index] = unescaped
+	}
+	return tokens, nil
+}
+
+// Joins unescaped reference tokens into a pointer.
+func Format(tokens []string) string {
+	pointer := ""
+	for _, token := range tokens {
+		pointer = Append(pointer, token)
+	}
+	return pointer
+}
+
+// Describes the reference token of the pointer that couldn't be found in the document.
+// Parent points to the last value of the pointer that exists.
+type NotFoundError struct {
+	Pointer string
+	Parent  string
+	Token   string
+	Reason  string
+}
+
+func (notFoundError *NotFoundError) Error() string {
+	parent := fmt.Sprintf("%q", notFoundError.Parent)
+	if len(notFoundError.Parent) == 0 {
+		parent = "the document root"
+	}
+	return fmt.Sprintf("can't resolve the JSON pointer %q at %s: %q %s", notFoundError.Pointer, parent, notFoundError.Token, notFoundError.Reason)
+}
+
+// Returns the value under the pointer of the document (objects and arrays decoded by encoding/json or the document package).
+// Returns a *NotFoundError naming the missing reference token if the value doesn't exist.
+func Get(document interface{}, pointer string) (interface{}, error) {
+	tokens, err := Parse(pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	value := document
+	for index, token := range tokens {
+		notFound := func(reason string) error {
+			return &NotFoundError{Pointer: Format(tokens), Parent: Format(tokens[:index]), Token: token, Reason: reason}
+		}
+		switch node := value.(type) {
+		case map[string]interface{}:
+			child, found := node[token]
+			if !found {
+				return nil, notFound("not found")
+			}
+			value = child
+		case []interface{}:
+			arrayIndex, isIndex := parseIndex(token)
+			if !isIndex {
+				return nil, notFound("isn't a valid array index")
+			}
+			if arrayIndex >= len(node) {
+				return nil, notFound(fmt.Sprintf("is out of range (%d items)", len(node)))
+			}
+			value = node[arrayIndex]
+		default:
+			return nil, notFound("not found, the value isn't an object or array")
+		}
+	}
+	return value, nil
+}
+
+// Parses the array index, leading zeros and the `-` (past the last item) aren't allowed.
+func parseIndex(token string) (int, bool) {
+	if len(token) == 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, false
+	}
+	for _, digit := range token {
+		if digit < '0' || digit > '9' {
+			return 0, false
+		}
+	}
+	index, err := strconv.Atoi(token)
+	return index, err == nil
+}
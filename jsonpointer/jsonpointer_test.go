@@ -0,0 +1,112 @@
+package jsonpointer_test
+
+import (
+	"testing"
+
+	"github.com/clearcodehq/openapi-linter/jsonpointer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppend(t *testing.T) {
+	Assert := assert.New(t)
+
+	t.Run("Append to the root pointer", func(t *testing.T) {
+		Assert.Equal("/definitions", jsonpointer.Append("", "definitions"))
+	})
+
+	t.Run("Escape special characters", func(t *testing.T) {
+		Assert.Equal("/paths/~1users~1{id}", jsonpointer.Append("/paths", "/users/{id}"))
+		Assert.Equal("/a~0b", jsonpointer.Append("", "a~b"))
+	})
+}
+
+func TestParse(t *testing.T) {
+	Assert := assert.New(t)
+
+	testCases := []struct {
+		pointer        string
+		expectedTokens []string
+	}{
+		{"", []string{}},
+		{"#", []string{}},
+		{"/", []string{""}},
+		{"/definitions/User", []string{"definitions", "User"}},
+		{"#/definitions/User", []string{"definitions", "User"}},
+		{"/paths/~1users~1{id}", []string{"paths", "/users/{id}"}},
+		{"/a~0b/~01", []string{"a~b", "~1"}},
+		{"/a.b/c d/[0]/200", []string{"a.b", "c d", "[0]", "200"}},
+		{"#/c%20d/%7Bid%7D", []string{"c d", "{id}"}},
+		{"/c%20d", []string{"c%20d"}},
+	}
+	for _, testCase := range testCases {
+		tokens, err := jsonpointer.Parse(testCase.pointer)
+
+		Assert.Nil(err, testCase.pointer)
+		Assert.Equal(testCase.expectedTokens, tokens, testCase.pointer)
+	}
+
+	t.Run("Invalid pointers", func(t *testing.T) {
+		for _, pointer := range []string{"definitions", "#definitions", "/a~2", "/a~", "#/a%zz"} {
+			_, err := jsonpointer.Parse(pointer)
+
+			Assert.NotNil(err, pointer)
+		}
+	})
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "/paths/~1users/a~0b", jsonpointer.Format([]string{"paths", "/users", "a~b"}))
+}
+
+func TestGet(t *testing.T) {
+	Assert := assert.New(t)
+	document := map[string]interface{}{
+		"definitions": map[string]interface{}{
+			"a.b":     "dots",
+			"c d":     "spaces",
+			"[0]":     "brackets",
+			"200":     "number",
+			"/users":  "slash",
+			"list":    []interface{}{"first", "second"},
+			"content": "text",
+		},
+	}
+
+	t.Run("Existing values", func(t *testing.T) {
+		testCases := map[string]interface{}{
+			"":                     document,
+			"#":                    document,
+			"#/definitions/a.b":    "dots",
+			"/definitions/c d":     "spaces",
+			"#/definitions/c%20d":  "spaces",
+			"/definitions/[0]":     "brackets",
+			"/definitions/200":     "number",
+			"/definitions/~1users": "slash",
+			"#/definitions/list/1": "second",
+		}
+		for pointer, expectedValue := range testCases {
+			value, err := jsonpointer.Get(document, pointer)
+
+			Assert.Nil(err, pointer)
+			Assert.Equal(expectedValue, value, pointer)
+		}
+	})
+
+	t.Run("Errors name the missing segment", func(t *testing.T) {
+		testCases := map[string]string{
+			"#/definitions/User":        `can't resolve the JSON pointer "/definitions/User" at "/definitions": "User" not found`,
+			"/components/schemas":       `can't resolve the JSON pointer "/components/schemas" at the document root: "components" not found`,
+			"/definitions/list/2":       `can't resolve the JSON pointer "/definitions/list/2" at "/definitions/list": "2" is out of range (2 items)`,
+			"/definitions/list/01":      `can't resolve the JSON pointer "/definitions/list/01" at "/definitions/list": "01" isn't a valid array index`,
+			"/definitions/content/type": `can't resolve the JSON pointer "/definitions/content/type" at "/definitions/content": "type" not found, the value isn't an object or array`,
+		}
+		for pointer, expectedError := range testCases {
+			_, err := jsonpointer.Get(document, pointer)
+
+			Assert.EqualError(err, expectedError, pointer)
+			notFoundError, isNotFound := err.(*jsonpointer.NotFoundError)
+			Assert.True(isNotFound, pointer)
+			Assert.NotNil(notFoundError)
+		}
+	})
+}
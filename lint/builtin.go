@@ -0,0 +1,251 @@
+package lint
+
+import (
+	"fmt"
+
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/references"
+	"github.com/clearcodehq/openapi-linter/report"
+	"github.com/clearcodehq/openapi-linter/validate"
+	validate_examples "github.com/clearcodehq/openapi-linter/validate-examples"
+	validate_refs "github.com/clearcodehq/openapi-linter/validate-refs"
+	validate_spec "github.com/clearcodehq/openapi-linter/validate-spec"
+	validate_unused "github.com/clearcodehq/openapi-linter/validate-unused"
+)
+
+// A rule backed by one of the existing checks
+type builtinRule struct {
+	id          string
+	description string
+	severity    string
+	check       func(id string, context *Context, document *document.Document) Result
+	// checks the options of the rule, the rule has no options if it's nil
+	options func(options map[string]interface{}) error
+}
+
+func (rule builtinRule) ID() string {
+	return rule.id
+}
+
+func (rule builtinRule) Description() string {
+	return rule.description
+}
+
+func (rule builtinRule) DefaultSeverity() string {
+	return rule.severity
+}
+
+func (rule builtinRule) Check(context *Context, document *document.Document) Result {
+	return rule.check(rule.id, context, document)
+}
+
+func (rule builtinRule) ValidateOptions(options map[string]interface{}) error {
+	if rule.options == nil {
+		return noOptions(options)
+	}
+	return rule.options(options)
+}
+
+// Returns all built-in rules, in the order they're run.
+// Unused components and orphaned files are warnings by default, they don't break anything.
+func BuiltinRules() []Rule {
+	return []Rule{
+		builtinRule{validate.Rule, validate.RuleDescription, report.SeverityError, onlyRule(validateSchemas), modeOptions},
+		builtinRule{validate.SchemaValuesRule, validate.SchemaValuesRuleDescription, report.SeverityError, onlyRule(validateSchemas), modeOptions},
+		builtinRule{references.CycleRule, references.CycleRuleDescription, report.SeverityError, onlyRule(validateSchemas), modeOptions},
+		builtinRule{validate_examples.Rule, validate_examples.RuleDescription, report.SeverityError, onlyRule(validateExamples), nil},
+		builtinRule{validate_spec.Rule, validate_spec.RuleDescription, report.SeverityError, validateSpec, nil},
+		builtinRule{references.UnresolvedRule, references.UnresolvedRuleDescription, report.SeverityError, validateRefs, nil},
+		builtinRule{references.UnusedComponentRule, references.UnusedComponentRuleDescription, report.SeverityWarning, onlyRule(findUnused), allowOptions},
+		builtinRule{references.OrphanedFileRule, references.OrphanedFileRuleDescription, report.SeverityWarning, onlyRule(findUnused), allowOptions},
+	}
+}
+
+// Returns the built-in rules with the IDs, all of them if there are no IDs.
+func SelectRules(ids []string) ([]Rule, error) {
+	builtinRules := BuiltinRules()
+	if len(ids) == 0 {
+		return builtinRules, nil
+	}
+	rules := []Rule{}
+	for _, id := range ids {
+		found := false
+		for _, rule := range builtinRules {
+			if rule.ID() == id {
+				rules = append(rules, rule)
+				found = true
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unknown rule %q", id)
+		}
+	}
+	return rules, nil
+}
+
+// Keeps only the findings and checks of the rule, the check is shared by several rules.
+func onlyRule(check func(id string, context *Context, document *document.Document) Result) func(string, *Context, *document.Document) Result {
+	return func(id string, context *Context, document *document.Document) Result {
+		all := check(id, context, document)
+		result := Result{Findings: []report.Finding{}, Checks: []report.Check{}}
+		for _, finding := range all.Findings {
+			if finding.Rule == id {
+				result.Findings = append(result.Findings, finding)
+			}
+		}
+		for _, check := range all.Checks {
+			if check.Rule == id {
+				result.Checks = append(result.Checks, check)
+			}
+		}
+		return result
+	}
+}
+
+// Validates the schemas of the document, also the values embedded in them and reference cycles.
+// The `mode` option selects where schemas are looked for, see validate.Mode.
+func validateSchemas(id string, context *Context, document *document.Document) Result {
+	mode := validate.ModeHeuristic
+	if option, isString := context.Option(id, "mode").(string); isString {
+		mode = validate.Mode(option)
+	}
+	return context.Once(validate.Rule+":"+string(mode)+":"+document.Path, func() interface{} {
+		validationErrors, checks := []validate.ValidationError{}, []report.Check{}
+		_ = validate.ValidateJSONFileInStore(context.Store, document.Path, mode, &validationErrors, &checks)
+		return validationResult(validationErrors, checks)
+	}).(Result)
+}
+
+// Validates the examples of the document against their schemas, examples of partial files aren't validated.
+func validateExamples(id string, context *Context, document *document.Document) Result {
+	if context.IsPartial(document.Path) {
+		return Result{}
+	}
+	return context.Once(validate_examples.Rule+":"+document.Path, func() interface{} {
+		checks := []report.Check{}
+		result := Result{Findings: []report.Finding{}}
+		for _, exampleError := range validate_examples.ScanDocumentForExampleErrors(context.Store, document, reachedFiles(context), &checks) {
+			result.Findings = append(result.Findings, exampleError.Finding())
+		}
+		result.Checks = checks
+		return result
+	}).(Result)
+}
+
+// Returns the files referenced as schemas or example values by the documents of the run, found once per run.
+func reachedFiles(context *Context) validate_examples.ReachedFiles {
+	return context.Once(validate_examples.Rule+":reached", func() interface{} {
+		uris := []string{}
+		for _, file := range context.Files {
+			uris = append(uris, document.URI(file))
+		}
+		return validate_examples.FindReachedFiles(context.Store, uris)
+	}).(validate_examples.ReachedFiles)
+}
+
+// Validates the OpenAPI document against the official schema of its version.
+func validateSpec(id string, context *Context, document *document.Document) Result {
+	validationErrors, checks := []validate.ValidationError{}, []report.Check{}
+	validate_spec.ValidateSpecDocument(document, &validationErrors, &checks)
+	return validationResult(validationErrors, checks)
+}
+
+// Checks every `$ref` of the document points to an existing document and value.
+func validateRefs(id string, context *Context, document *document.Document) Result {
+	validationErrors, checks := []validate.ValidationError{}, []report.Check{}
+	_ = validate_refs.ValidateRefsInFile(context.Store, document.Path, &validationErrors, &checks)
+	return validationResult(validationErrors, checks)
+}
+
+// Finds the components and documents that no operation reaches, the graph of all documents is built once per run.
+// The `allow` option lists intentionally public components and documents, see validate_unused.FindUnusedInDir.
+// Both rules share the result, so the allowlists of both rules are joined and they agree about what is used.
+func findUnused(id string, context *Context, document *document.Document) Result {
+	byFile := context.Once("unused", func() interface{} {
+		allowlist := []string{}
+		for _, rule := range []string{references.UnusedComponentRule, references.OrphanedFileRule} {
+			allow, _ := stringsOption(context.Option(rule, "allow"))
+			allowlist = append(allowlist, allow...)
+		}
+		byFile := map[string][]validate.ValidationError{}
+		for _, validationError := range validate_unused.FindUnused(context.Store, context.Dir, context.Files, allowlist) {
+			byFile[validationError.FilePath] = append(byFile[validationError.FilePath], validationError)
+		}
+		return byFile
+	}).(map[string][]validate.ValidationError)
+
+	return validationResult(byFile[document.Path], []report.Check{
+		{File: document.Path, Rule: references.UnusedComponentRule},
+		{File: document.Path, Rule: references.OrphanedFileRule},
+	})
+}
+
+func validationResult(validationErrors []validate.ValidationError, checks []report.Check) Result {
+	result := Result{Findings: []report.Finding{}, Checks: checks}
+	for _, validationError := range validationErrors {
+		result.Findings = append(result.Findings, validationError.Finding())
+	}
+	return result
+}
+
+// Checks the `mode` option, see validate.Mode.
+func modeOptions(options map[string]interface{}) error {
+	for name, value := range options {
+		if name != "mode" {
+			return fmt.Errorf("unknown option %q", name)
+		}
+		mode, isString := value.(string)
+		if !isString {
+			return fmt.Errorf("the option %q must be a string", name)
+		}
+		if err := validate.ValidateMode(validate.Mode(mode)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Checks the `allow` option, a list of allowlist patterns.
+func allowOptions(options map[string]interface{}) error {
+	for name, value := range options {
+		if name != "allow" {
+			return fmt.Errorf("unknown option %q", name)
+		}
+		patterns, isList := stringsOption(value)
+		if !isList {
+			return fmt.Errorf("the option %q must be a list of strings", name)
+		}
+		for _, pattern := range patterns {
+			if err := validate_unused.ValidatePattern(pattern); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func noOptions(options map[string]interface{}) error {
+	for name := range options {
+		return fmt.Errorf("unknown option %q, the rule has no options", name)
+	}
+	return nil
+}
+
+// Converts the option to a list of strings, options decoded from YAML or JSON are lists of values.
+func stringsOption(value interface{}) ([]string, bool) {
+	switch values := value.(type) {
+	case []string:
+		return values, true
+	case []interface{}:
+		texts := []string{}
+		for _, item := range values {
+			text, isString := item.(string)
+			if !isString {
+				return nil, false
+			}
+			texts = append(texts, text)
+		}
+		return texts, true
+	}
+	return nil, false
+}
@@ -0,0 +1,159 @@
+package lint
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/bmatcuk/doublestar"
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/report"
+	"github.com/clearcodehq/openapi-linter/validate"
+)
+
+// Partial files unless configured otherwise
+var DefaultPartials = []string{"**/*.partial.json", "**/*.partial.yaml", "**/*.partial.yml"}
+
+// Settings of a run, every setting is optional.
+// Globs are matched against paths relative to the scanned directory, e.g. `specs/**/*.yaml`.
+type Options struct {
+	// Files to scan, all JSON and YAML files if it's empty
+	Include []string
+	// Files to skip
+	Exclude []string
+	// Files holding fragments of documents, DefaultPartials if it's nil
+	Partials []string
+	// Severities of findings by rule IDs, overriding the default severities of rules
+	Severities map[string]string
+	// Options of rules by rule IDs
+	RuleOptions map[string]map[string]interface{}
+}
+
+func (options Options) partials() []string {
+	if options.Partials == nil {
+		return DefaultPartials
+	}
+	return options.Partials
+}
+
+// Checks the globs, the severities and the options of the rules.
+func (options Options) Validate(rules []Rule) error {
+	for _, globs := range [][]string{options.Include, options.Exclude, options.Partials} {
+		for _, glob := range globs {
+			// the glob is matched against itself, so every bracket expression is parsed
+			if _, err := doublestar.Match(glob, glob); err != nil {
+				return fmt.Errorf("invalid glob %q: %s", glob, err)
+			}
+		}
+	}
+	for id, severity := range options.Severities {
+		if err := report.ValidateSeverity(severity); err != nil {
+			return fmt.Errorf("rule %s: %s", id, err)
+		}
+	}
+	for _, rule := range rules {
+		ruleOptions := options.RuleOptions[rule.ID()]
+		if len(ruleOptions) == 0 {
+			continue
+		}
+		validator, isValidator := rule.(OptionsValidator)
+		if !isValidator {
+			return fmt.Errorf("rule %s: the rule has no options", rule.ID())
+		}
+		if err := validator.ValidateOptions(ruleOptions); err != nil {
+			return fmt.Errorf("rule %s: %s", rule.ID(), err)
+		}
+	}
+	return nil
+}
+
+// Runs the rules over all JSON and YAML documents within the directory, every document is read and parsed once.
+// Files that can't be read or parsed are reported with the document.ParseErrorRule and aren't checked by the rules.
+// Findings get the configured severity of their rule, or the default one. Returns findings sorted by the file, position and rule.
+// Findings within a node with an IgnoreExtension listing their rule are returned as suppressed findings.
+func Lint(dir string, rules []Rule, options Options) (Result, error) {
+	if err := options.Validate(rules); err != nil {
+		return Result{}, err
+	}
+	foundFiles, err := validate.FindJsonFiles(dir)
+	if err != nil {
+		return Result{}, err
+	}
+	files := []string{}
+	for _, file := range foundFiles {
+		if (len(options.Include) == 0 || matchAny(options.Include, dir, file)) && !matchAny(options.Exclude, dir, file) {
+			files = append(files, file)
+		}
+	}
+
+	context := NewContext(dir, options)
+	result := Result{Findings: []report.Finding{}, Checks: []report.Check{}, Suppressed: []report.Suppressed{}, Files: files}
+	documents := []*document.Document{}
+	for _, file := range files {
+		// documents keep the path as found in the directory, so findings do too
+		lintedDocument, fileErr := document.Load(file)
+		if fileErr != nil {
+			result.Findings = append(result.Findings, validate.FileError(file, fileErr).Finding())
+			continue
+		}
+		context.Store.Add(lintedDocument)
+		context.Files = append(context.Files, file)
+		documents = append(documents, lintedDocument)
+	}
+
+	knownRules := map[string]bool{}
+	for _, rule := range append(BuiltinRules(), rules...) {
+		knownRules[rule.ID()] = true
+	}
+	for _, lintedDocument := range documents {
+		documentResult := Result{}
+		for _, rule := range rules {
+			ruleResult := rule.Check(context, lintedDocument)
+			severity := rule.DefaultSeverity()
+			if configured, isConfigured := options.Severities[rule.ID()]; isConfigured {
+				severity = configured
+			}
+			for index := range ruleResult.Findings {
+				ruleResult.Findings[index].Severity = severity
+			}
+			documentResult.Add(ruleResult)
+		}
+		suppressions, invalidSuppressions := findSuppressions(lintedDocument.Path, lintedDocument, knownRules)
+		documentResult.Findings, documentResult.Suppressed = suppress(documentResult.Findings, suppressions)
+		documentResult.Findings = append(documentResult.Findings, invalidSuppressions...)
+		result.Add(documentResult)
+	}
+	result.Findings = report.Sort(result.Findings)
+	result.Suppressed = report.SortSuppressed(result.Suppressed)
+	return result, nil
+}
+
+// Returns the rules as described in reports that list rules, with the rules of files that can't be parsed and invalid suppressions.
+// Rules are described with their configured severities.
+func ReportRules(rules []Rule, options Options) []report.Rule {
+	reportRules := []report.Rule{}
+	for _, rule := range rules {
+		severity := rule.DefaultSeverity()
+		if configured, isConfigured := options.Severities[rule.ID()]; isConfigured {
+			severity = configured
+		}
+		reportRules = append(reportRules, report.Rule{ID: rule.ID(), Description: rule.Description(), Severity: severity})
+	}
+	return append(reportRules,
+		report.Rule{ID: document.ParseErrorRule, Description: document.ParseErrorRuleDescription},
+		report.Rule{ID: InvalidIgnoreRule, Description: InvalidIgnoreRuleDescription})
+}
+
+// Determines if the path of the file, relative to the directory, matches any of the globs.
+func matchAny(globs []string, dir string, path string) bool {
+	relativePath, err := filepath.Rel(dir, path)
+	if err != nil {
+		return false
+	}
+	relativePath = filepath.ToSlash(relativePath)
+	for _, glob := range globs {
+		if matched, _ := doublestar.Match(glob, relativePath); matched {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,241 @@
+package lint
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func testsDir() string {
+	_, testsFile, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(testsFile), "..", "tests", "lint", "lint")
+}
+
+// Reports every document once, counting how many times the shared computation runs
+type countingRule struct {
+	computed *int
+}
+
+func (rule countingRule) ID() string              { return "counting" }
+func (rule countingRule) Description() string     { return "Counts documents." }
+func (rule countingRule) DefaultSeverity() string { return report.SeverityError }
+func (rule countingRule) Check(context *Context, document *document.Document) Result {
+	context.Once("counting", func() interface{} {
+		*rule.computed++
+		return nil
+	})
+	return Result{
+		Findings: []report.Finding{{File: document.Path, Rule: rule.ID(), Message: "checked"}},
+		Checks:   []report.Check{{File: document.Path, Rule: rule.ID()}},
+	}
+}
+
+func TestLint(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	dir := testsDir()
+	spec, broken := filepath.Join(dir, "openapi.yaml"), filepath.Join(dir, "broken.yaml")
+	expectedFindings := []string{
+		broken + ":2 parse-error error",
+		spec + ":9:17 unresolved-ref error",
+		spec + ":14:13 valid-example error",
+		spec + ":26:5 unused-component warning",
+	}
+
+	// WHEN
+	result, err := Lint(dir, BuiltinRules(), Options{})
+
+	// THEN
+	Assert.Nil(err)
+	findings := []string{}
+	for _, finding := range result.Findings {
+		findings = append(findings, finding.Location()+" "+finding.Rule+" "+finding.Severity)
+	}
+	Assert.Equal(expectedFindings, findings)
+	checkedRules := map[string]bool{}
+	for _, check := range result.Checks {
+		Assert.Equal(spec, check.File, "Files that can't be parsed aren't checked by rules")
+		checkedRules[check.Rule] = true
+	}
+	for _, rule := range []string{"valid-schema", "ref-cycle", "valid-example", "valid-spec", "unresolved-ref", "unused-component", "orphaned-file"} {
+		Assert.True(checkedRules[rule], rule)
+	}
+}
+
+func TestLintWithCustomRule(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	computed := 0
+	rules := []Rule{countingRule{&computed}}
+
+	// WHEN
+	result, err := Lint(testsDir(), rules, Options{})
+
+	// THEN
+	Assert.Nil(err)
+	Assert.Len(result.Findings, 2, "The parse error and the finding of the parsed document")
+	Assert.Len(result.Checks, 1)
+	Assert.Equal(1, computed, "Shared computations run once per run")
+}
+
+func TestSelectRules(t *testing.T) {
+	Assert := assert.New(t)
+
+	// WHEN
+	rules, err := SelectRules([]string{"unresolved-ref", "valid-spec"})
+
+	// THEN
+	Assert.Nil(err)
+	Assert.Len(rules, 2)
+	Assert.Equal("unresolved-ref", rules[0].ID())
+	Assert.Equal("valid-spec", rules[1].ID())
+
+	// WHEN
+	_, err = SelectRules([]string{"missing-rule"})
+
+	// THEN
+	Assert.EqualError(err, `unknown rule "missing-rule"`)
+}
+
+func TestLintWithOptions(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	dir := testsDir()
+	rules, _ := SelectRules([]string{"unused-component", "valid-example"})
+	options := Options{
+		Exclude:     []string{"broken.yaml"},
+		Partials:    []string{"*.yaml"},
+		Severities:  map[string]string{"unused-component": report.SeverityHint},
+		RuleOptions: map[string]map[string]interface{}{"unused-component": {"allow": []interface{}{"#/components/schemas/Unused"}}},
+	}
+
+	// WHEN
+	result, err := Lint(dir, rules, options)
+
+	// THEN
+	Assert.Nil(err)
+	Assert.Empty(result.Findings, "Excluded files aren't parsed, examples of partial files aren't validated and allowed components are used")
+
+	// GIVEN
+	options.RuleOptions = nil
+
+	// WHEN
+	result, err = Lint(dir, rules, options)
+
+	// THEN
+	Assert.Nil(err)
+	Assert.Len(result.Findings, 1)
+	Assert.Equal(report.SeverityHint, result.Findings[0].Severity, "Severities are configured by rules")
+
+	// GIVEN
+	options.RuleOptions = map[string]map[string]interface{}{"valid-example": {"mode": "openapi"}}
+
+	// WHEN
+	_, err = Lint(dir, rules, options)
+
+	// THEN
+	Assert.EqualError(err, `rule valid-example: unknown option "mode", the rule has no options`)
+}
+
+func TestLintSharesAllowlistOfUnusedRules(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	rules, _ := SelectRules([]string{"unused-component", "orphaned-file"})
+	options := Options{
+		Exclude:     []string{"broken.yaml"},
+		RuleOptions: map[string]map[string]interface{}{"orphaned-file": {"allow": []interface{}{"#/components/schemas/Unused"}}},
+	}
+
+	// WHEN
+	result, err := Lint(testsDir(), rules, options)
+
+	// THEN
+	Assert.Nil(err)
+	Assert.Empty(result.Findings, "Both rules use the allowlists of both rules")
+}
+
+func TestLintWithSuppressions(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	dir := filepath.Join(testsDir(), "..", "suppress")
+	spec := filepath.Join(dir, "openapi.yaml")
+	rules, _ := SelectRules([]string{"valid-example", "unused-component", "valid-spec"})
+
+	// WHEN
+	result, err := Lint(dir, rules, Options{})
+
+	// THEN
+	Assert.Nil(err)
+	findings := []string{}
+	for _, finding := range result.Findings {
+		findings = append(findings, finding.Location()+" "+finding.Rule+" "+finding.Message)
+	}
+	Assert.Equal([]string{
+		spec + ":23:13 valid-example #/paths/~1users/get/responses/404/content/application~1json/example: id: Invalid type. Expected: integer, given: string",
+		spec + ":35:7 invalid-lint-ignore x-lint-ignore suppresses the unknown rule \"valid-exampel\"",
+		spec + ":37:7 invalid-lint-ignore x-lint-ignore must give the reason of the suppression",
+	}, findings, "Suppressions only apply to their rules and invalid suppressions are reported")
+	Assert.Len(result.Suppressed, 2)
+	Assert.Equal("valid-example", result.Suppressed[0].Rule)
+	Assert.Equal("/paths/~1users/get/responses/200/content/application~1json", result.Suppressed[0].SuppressedBy)
+	Assert.Equal("the example documents the legacy format", result.Suppressed[0].Reason)
+	Assert.Equal("unused-component", result.Suppressed[1].Rule)
+	Assert.Equal("/components/schemas/Legacy", result.Suppressed[1].Pointer)
+	Assert.Equal("/components", result.Suppressed[1].SuppressedBy)
+}
+
+func TestSuppressFindings(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	spec := filepath.Join(testsDir(), "..", "suppress", "openapi.yaml")
+	findings := []report.Finding{
+		{File: spec, Pointer: "/paths/~1users/get/responses/200/content/application~1json", Rule: "valid-example", Message: "invalid"},
+		{File: spec, Pointer: "/paths/~1users/get/responses/404/content/application~1json", Rule: "valid-example", Message: "invalid"},
+		{File: spec, Pointer: "/components/schemas/Legacy", Rule: "valid-spec", Message: "invalid"},
+		{File: filepath.Join(testsDir(), "missing.yaml"), Rule: "valid-spec", Message: "invalid"},
+	}
+
+	// WHEN
+	kept, suppressed := SuppressFindings(document.NewStore(), []string{spec}, findings)
+
+	// THEN
+	Assert.Len(kept, 5)
+	Assert.Equal([]report.Finding{findings[3], findings[1], findings[2]}, kept[:3], "Invalid suppressions aren't applied and files that can't be read are kept")
+	Assert.Equal(InvalidIgnoreRule, kept[3].Rule, "Invalid suppressions are reported")
+	Assert.Equal("/components/x-lint-ignore/1", kept[3].Pointer)
+	Assert.Equal(InvalidIgnoreRule, kept[4].Rule)
+	Assert.Equal("/components/x-lint-ignore/2", kept[4].Pointer)
+	Assert.Len(suppressed, 1)
+	Assert.Equal(findings[0], suppressed[0].Finding)
+	Assert.Equal("the example documents the legacy format", suppressed[0].Reason)
+}
+
+func TestSuppressFindingsFromStore(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN a document that is only in the store
+	spec := filepath.Join(testsDir(), "in_store.yaml")
+	parsed, err := document.Parse(spec, []byte("paths:\n  /users:\n    x-lint-ignore:\n      rules: [valid-spec]\n      reason: generated\n"))
+	Assert.Nil(err)
+	store := document.NewStore()
+	store.Add(parsed)
+	findings := []report.Finding{{File: spec, Pointer: "/paths/~1users/get", Rule: "valid-spec", Message: "invalid"}}
+
+	// WHEN
+	kept, suppressed := SuppressFindings(store, []string{spec}, findings)
+
+	// THEN
+	Assert.Empty(kept, "The document isn't read from the file again")
+	Assert.Len(suppressed, 1)
+	Assert.Equal("/paths/~1users", suppressed[0].SuppressedBy)
+}
@@ -0,0 +1,81 @@
+// Rule engine running every enabled check over the documents of a directory in one pass.
+package lint
+
+import (
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/report"
+)
+
+// A single check of the linter.
+// Check is called once for every document that could be parsed, with the context shared by all rules and documents of the run.
+type Rule interface {
+	// Identifies the rule in reports and configuration, e.g. valid-schema
+	ID() string
+	// Describes the rule in reports that list rules
+	Description() string
+	// Severity of findings, unless configured otherwise
+	DefaultSeverity() string
+	// Returns the findings of the rule in the document and the list of checked values
+	Check(context *Context, document *document.Document) Result
+}
+
+// Rules with options check them before the run, rules that don't implement it have no options
+type OptionsValidator interface {
+	ValidateOptions(options map[string]interface{}) error
+}
+
+// Findings and checks of a rule or a whole run
+type Result struct {
+	Findings []report.Finding
+	Checks   []report.Check
+	// Findings silenced by `x-lint-ignore` extensions, only set for a whole run
+	Suppressed []report.Suppressed
+	// Paths of all scanned files, also the ones that can't be parsed, only set for a whole run
+	Files []string
+}
+
+// Appends the findings, checks and suppressed findings of the other result.
+func (result *Result) Add(other Result) {
+	result.Findings = append(result.Findings, other.Findings...)
+	result.Checks = append(result.Checks, other.Checks...)
+	result.Suppressed = append(result.Suppressed, other.Suppressed...)
+	result.Files = append(result.Files, other.Files...)
+}
+
+// Store informations shared by all rules during a run
+type Context struct {
+	// The scanned directory
+	Dir string
+	// Every document is read and parsed once, references between documents are resolved in memory
+	Store *document.Store
+	// Paths of all documents that could be parsed
+	Files []string
+
+	options Options
+	results map[string]interface{}
+}
+
+func NewContext(dir string, options Options) *Context {
+	return &Context{Dir: dir, Store: document.NewStore(), Files: []string{}, options: options, results: map[string]interface{}{}}
+}
+
+// Returns the value of the option of the rule, nil if it isn't set.
+func (context *Context) Option(rule string, name string) interface{} {
+	return context.options.RuleOptions[rule][name]
+}
+
+// Determines if the file holds a fragment of a document, e.g. `user.partial.yaml`.
+func (context *Context) IsPartial(path string) bool {
+	return matchAny(context.options.partials(), context.Dir, path)
+}
+
+// Returns the result computed for the key, compute is called only the first time.
+// Rules sharing an expensive computation (e.g. one validation reporting findings of several rules) use the same key.
+func (context *Context) Once(key string, compute func() interface{}) interface{} {
+	if result, computed := context.results[key]; computed {
+		return result
+	}
+	result := compute()
+	context.results[key] = result
+	return result
+}
@@ -0,0 +1,210 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/jsonpointer"
+	"github.com/clearcodehq/openapi-linter/references"
+	"github.com/clearcodehq/openapi-linter/report"
+)
+
+// Vendor extension suppressing findings of rules within the node that holds it, e.g.
+//
+//	x-lint-ignore:
+//	  rules: [valid-example]
+//	  reason: the example documents the legacy format
+//
+// The value is a suppression or a list of them, rules are a rule ID or a list of them.
+const IgnoreExtension = "x-lint-ignore"
+
+// Rule reported with findings about suppressions that can't be applied
+const InvalidIgnoreRule = "invalid-lint-ignore"
+
+// Describes the rule in reports that list rules
+const InvalidIgnoreRuleDescription = "`x-lint-ignore` must list IDs of existing rules and the reason of the suppression."
+
+// Findings of the rules within the node are suppressed
+type suppression struct {
+	// JSON Pointer to the node holding the extension
+	pointer string
+	rules   []string
+	reason  string
+}
+
+// Determines if the suppression applies to the finding.
+func (suppression suppression) matches(finding report.Finding) bool {
+	if !references.Contains(suppression.pointer, finding.Pointer) {
+		return false
+	}
+	for _, rule := range suppression.rules {
+		if rule == finding.Rule {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the suppressions of the document found at the path, suppressions that can't be applied are returned as findings.
+// Rules are known by their IDs, a suppression listing an unknown rule is most likely a typo.
+func findSuppressions(path string, document *document.Document, known map[string]bool) ([]suppression, []report.Finding) {
+	suppressions, findings := []suppression{}, []report.Finding{}
+	invalid := func(pointer string, message string) {
+		position := document.Position(pointer)
+		findings = append(findings, report.Finding{
+			File:     path,
+			Pointer:  pointer,
+			Line:     position.Line,
+			Column:   position.Column,
+			Rule:     InvalidIgnoreRule,
+			Message:  message,
+			Severity: report.SeverityError,
+		})
+	}
+
+	var visit func(pointer string, value interface{})
+	visit = func(pointer string, value interface{}) {
+		switch node := value.(type) {
+		case map[string]interface{}:
+			for _, key := range sortedKeys(node) {
+				if key == IgnoreExtension {
+					extensionPointer := jsonpointer.Append(pointer, key)
+					for index, entry := range ignoreEntries(node[key]) {
+						entryPointer := extensionPointer
+						if _, isList := node[key].([]interface{}); isList {
+							entryPointer = jsonpointer.Append(extensionPointer, fmt.Sprint(index))
+						}
+						parsed, err := parseSuppression(pointer, entry, known)
+						if err != nil {
+							invalid(entryPointer, err.Error())
+							continue
+						}
+						suppressions = append(suppressions, parsed)
+					}
+					continue
+				}
+				visit(jsonpointer.Append(pointer, key), node[key])
+			}
+		case []interface{}:
+			for index, item := range node {
+				visit(jsonpointer.Append(pointer, fmt.Sprint(index)), item)
+			}
+		}
+	}
+	visit("", document.Root)
+	return suppressions, findings
+}
+
+// Returns the suppressions written in the extension, a single suppression or a list of them.
+func ignoreEntries(value interface{}) []interface{} {
+	if entries, isList := value.([]interface{}); isList {
+		return entries
+	}
+	return []interface{}{value}
+}
+
+func parseSuppression(pointer string, value interface{}, known map[string]bool) (suppression, error) {
+	entry, isObject := value.(map[string]interface{})
+	if !isObject {
+		return suppression{}, fmt.Errorf("%s must be an object with the rules and the reason", IgnoreExtension)
+	}
+	for key := range entry {
+		if key != "rules" && key != "reason" {
+			return suppression{}, fmt.Errorf("unknown property %q of %s", key, IgnoreExtension)
+		}
+	}
+	rules, isList := stringsOption(entry["rules"])
+	if rule, isString := entry["rules"].(string); isString {
+		rules, isList = []string{rule}, true
+	}
+	if !isList || len(rules) == 0 {
+		return suppression{}, fmt.Errorf("%s must list the IDs of suppressed rules", IgnoreExtension)
+	}
+	for _, rule := range rules {
+		if !known[rule] {
+			return suppression{}, fmt.Errorf("%s suppresses the unknown rule %q", IgnoreExtension, rule)
+		}
+	}
+	reason, _ := entry["reason"].(string)
+	if len(strings.TrimSpace(reason)) == 0 {
+		return suppression{}, fmt.Errorf("%s must give the reason of the suppression", IgnoreExtension)
+	}
+	return suppression{pointer: pointer, rules: rules, reason: reason}, nil
+}
+
+// Moves the findings matching any of the suppressions out of the findings.
+// A finding matching several suppressions is suppressed by the innermost one.
+func suppress(findings []report.Finding, suppressions []suppression) ([]report.Finding, []report.Suppressed) {
+	kept, suppressed := []report.Finding{}, []report.Suppressed{}
+	for _, finding := range findings {
+		var matched *suppression
+		for index := range suppressions {
+			if suppressions[index].matches(finding) && (matched == nil || len(suppressions[index].pointer) > len(matched.pointer)) {
+				matched = &suppressions[index]
+			}
+		}
+		if matched == nil {
+			kept = append(kept, finding)
+			continue
+		}
+		suppressed = append(suppressed, report.Suppressed{Finding: finding, SuppressedBy: matched.pointer, Reason: matched.reason})
+	}
+	return kept, suppressed
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Moves the findings suppressed by IgnoreExtension in their documents out of the findings,
+// for commands that report findings of the built-in rules without running the linter.
+// Documents of the scanned files and of the findings are loaded from the store, findings of files that can't be read are kept.
+// Suppressions of the scanned files that can't be applied are returned as findings of the InvalidIgnoreRule, like Lint does.
+func SuppressFindings(store *document.Store, files []string, findings []report.Finding) ([]report.Finding, []report.Suppressed) {
+	knownRules := map[string]bool{}
+	for _, rule := range BuiltinRules() {
+		knownRules[rule.ID()] = true
+	}
+	// files are known by their URIs, so the same file given by different paths is read once
+	byURI, paths, uris := map[string][]report.Finding{}, map[string]string{}, []string{}
+	addFile := func(path string) string {
+		uri := document.URI(path)
+		if _, seen := paths[uri]; !seen {
+			paths[uri] = path
+			uris = append(uris, uri)
+		}
+		return uri
+	}
+	scanned := map[string]bool{}
+	for _, file := range files {
+		scanned[addFile(file)] = true
+	}
+	for _, finding := range findings {
+		uri := addFile(finding.File)
+		byURI[uri] = append(byURI[uri], finding)
+	}
+
+	kept, suppressed := []report.Finding{}, []report.Suppressed{}
+	for _, uri := range uris {
+		suppressedDocument, err := store.Load(uri)
+		if err != nil {
+			kept = append(kept, byURI[uri]...)
+			continue
+		}
+		suppressions, invalidSuppressions := findSuppressions(paths[uri], suppressedDocument, knownRules)
+		fileFindings, fileSuppressed := suppress(byURI[uri], suppressions)
+		kept = append(kept, fileFindings...)
+		if scanned[uri] {
+			kept = append(kept, invalidSuppressions...)
+		}
+		suppressed = append(suppressed, fileSuppressed...)
+	}
+	return report.Sort(kept), report.SortSuppressed(suppressed)
+}
@@ -0,0 +1,266 @@
+// Following of `$ref`s between and within documents.
+package references
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/jsonpointer"
+)
+
+// Name of the check reported with every reference cycle that can't be satisfied
+const CycleRule = "ref-cycle"
+
+// Describes the check in reports that list rules
+const CycleRuleDescription = "Schemas must not reference themselves without descending into a property or an item."
+
+// A value of a document, identified by the canonical URI of the document and the JSON Pointer
+type Node struct {
+	URI     string
+	Pointer string
+}
+
+// Formats the node as a reference relative to the document identified by the base URI.
+// e.g. #/components/schemas/User or ../user.yaml#/User
+func (node Node) Relative(base string) string {
+	if node.URI == base {
+		return "#" + node.Pointer
+	}
+	path := document.FilePath(node.URI)
+	if relativePath, err := filepath.Rel(filepath.Dir(document.FilePath(base)), path); err == nil {
+		path = filepath.ToSlash(relativePath)
+	}
+	return path + "#" + node.Pointer
+}
+
+// Keywords that apply their schemas to the same value as the schema that holds them.
+// A cycle of these keywords and `$ref`s never ends, unlike a cycle through properties or items.
+var (
+	sameValueKeywords      = []string{"else", "if", "not", "then"}
+	sameValueMapKeywords   = []string{"dependencies", "dependentSchemas"}
+	sameValueArrayKeywords = []string{"allOf", "anyOf", "oneOf"}
+)
+
+// Keywords that apply their schemas to the nested values (properties or items)
+var (
+	nestedValueKeywords      = []string{"additionalItems", "additionalProperties", "contains", "items", "propertyNames", "unevaluatedItems", "unevaluatedProperties"}
+	nestedValueMapKeywords   = []string{"patternProperties", "properties"}
+	nestedValueArrayKeywords = []string{"items", "prefixItems"}
+)
+
+// Finds the reference cycles that can't be satisfied, reachable from the value at the pointer of the document.
+// A cycle can't be satisfied if a schema reaches itself through `$ref`s (and `allOf`, `anyOf`, `oneOf`, `not`, ...)
+// without descending into a property or an item, e.g. `A: {$ref: B}, B: {$ref: A}` or `A: {allOf: [{$ref: A}]}`,
+// validators would apply it to the same value forever. Recursive schemas (e.g. `children: {items: {$ref: Node}}`) are fine.
+// Cycles are returned from their first node (the lowest one) back to it, references that can't be resolved are skipped.
+func FindCycles(store *document.Store, uri string, pointer string) [][]Node {
+	return findCycles(store, []Node{{uri, pointer}})
+}
+
+// Finds the cycles that can't be satisfied through `$ref`s of the document, each cycle is returned only once,
+// by the document that holds its first node.
+func FindDocumentCycles(store *document.Store, uri string) [][]Node {
+	root, err := store.Get(uri, "")
+	if err != nil {
+		return nil
+	}
+	starts := []Node{}
+	eachReference("", root, func(pointer string, ref string) {
+		starts = append(starts, Node{uri, pointer})
+	})
+
+	cycles := [][]Node{}
+	for _, cycle := range findCycles(store, starts) {
+		if cycle[0].URI == uri {
+			cycles = append(cycles, cycle)
+		}
+	}
+	sort.SliceStable(cycles, func(i, j int) bool {
+		return cycles[i][0].Pointer < cycles[j][0].Pointer
+	})
+	return cycles
+}
+
+// Visits every node reachable from the starts and records the cycles of nodes applied to the same value.
+// Every cycle is recorded once.
+func findCycles(store *document.Store, starts []Node) [][]Node {
+	finder := cycleFinder{store: store, states: map[Node]int{}}
+	reached := map[Node]bool{}
+	queue := []Node{}
+	for _, start := range starts {
+		if !reached[start] {
+			reached[start] = true
+			queue = append(queue, start)
+		}
+	}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		finder.visit(node, nil)
+		for _, next := range append(finder.sameValueNodes(node), finder.nestedValueNodes(node)...) {
+			if !reached[next] {
+				reached[next] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return finder.cycles
+}
+
+// Formats the cycle relative to the document identified by the base URI.
+// e.g. #/components/schemas/A -> #/components/schemas/B -> #/components/schemas/A
+func FormatCycle(base string, cycle []Node) string {
+	nodes := make([]string, 0, len(cycle)+1)
+	for _, node := range append(cycle, cycle[0]) {
+		nodes = append(nodes, node.Relative(base))
+	}
+	return strings.Join(nodes, " -> ")
+}
+
+// Returns an error describing the first cycle that can't be satisfied, reachable from the value at the pointer of the document.
+// Validators must not be given such schemas, they'd never finish.
+func CheckCycles(store *document.Store, uri string, pointer string) error {
+	cycles := FindCycles(store, uri, pointer)
+	if len(cycles) == 0 {
+		return nil
+	}
+	return fmt.Errorf("the schema has a reference cycle that can't be satisfied: %s", FormatCycle(uri, cycles[0]))
+}
+
+// States of the nodes of the depth-first search
+const (
+	unvisited = iota
+	visiting
+	visited
+)
+
+type cycleFinder struct {
+	store  *document.Store
+	states map[Node]int
+	cycles [][]Node
+}
+
+// Visits the nodes that apply to the same value as the node, depth-first, and records the cycles.
+func (finder *cycleFinder) visit(node Node, path []Node) {
+	switch finder.states[node] {
+	case visited:
+		return
+	case visiting:
+		for index, pathNode := range path {
+			if pathNode == node {
+				finder.cycles = append(finder.cycles, RotateCycle(path[index:]))
+				return
+			}
+		}
+		return
+	}
+
+	finder.states[node] = visiting
+	path = append(path, node)
+	for _, next := range finder.sameValueNodes(node) {
+		finder.visit(next, path)
+	}
+	finder.states[node] = visited
+}
+
+// Returns the nodes applied to the same value as the node: the target of `$ref` and the sub-schemas of `allOf`, `not`, ...
+func (finder *cycleFinder) sameValueNodes(node Node) []Node {
+	object := finder.object(node)
+	if object == nil {
+		return nil
+	}
+	if ref, isString := object["$ref"].(string); isString {
+		uri, pointer, err := document.Locate(node.URI, ref)
+		if err != nil {
+			return nil
+		}
+		return []Node{{uri, pointer}}
+	}
+	return subSchemas(node, object, sameValueKeywords, sameValueMapKeywords, sameValueArrayKeywords)
+}
+
+// Returns the nodes applied to the nested values (properties and items) of the value of the node.
+func (finder *cycleFinder) nestedValueNodes(node Node) []Node {
+	object := finder.object(node)
+	if object == nil || object["$ref"] != nil {
+		return nil
+	}
+	return subSchemas(node, object, nestedValueKeywords, nestedValueMapKeywords, nestedValueArrayKeywords)
+}
+
+func (finder *cycleFinder) object(node Node) map[string]interface{} {
+	value, err := finder.store.Get(node.URI, node.Pointer)
+	if err != nil {
+		return nil
+	}
+	object, _ := value.(map[string]interface{})
+	return object
+}
+
+// Returns the sub-schemas of the keywords in the order of keywords (and keys).
+func subSchemas(node Node, object map[string]interface{}, keywords []string, mapKeywords []string, arrayKeywords []string) []Node {
+	nodes := []Node{}
+	add := func(pointer string, value interface{}) {
+		if _, isObject := value.(map[string]interface{}); isObject {
+			nodes = append(nodes, Node{node.URI, pointer})
+		}
+	}
+	for _, keyword := range keywords {
+		add(jsonpointer.Append(node.Pointer, keyword), object[keyword])
+	}
+	for _, keyword := range mapKeywords {
+		values, _ := object[keyword].(map[string]interface{})
+		for _, key := range sortedKeys(values) {
+			add(jsonpointer.Append(jsonpointer.Append(node.Pointer, keyword), key), values[key])
+		}
+	}
+	for _, keyword := range arrayKeywords {
+		values, _ := object[keyword].([]interface{})
+		for index, value := range values {
+			add(jsonpointer.Append(jsonpointer.Append(node.Pointer, keyword), strconv.Itoa(index)), value)
+		}
+	}
+	return nodes
+}
+
+// Rotates the cycle, so it starts from its lowest node (by the URI and pointer).
+// Every rotation of the same cycle starts from the same node, the cycle is reported there.
+func RotateCycle(cycle []Node) []Node {
+	first := 0
+	for index, node := range cycle {
+		if node.URI < cycle[first].URI || (node.URI == cycle[first].URI && node.Pointer < cycle[first].Pointer) {
+			first = index
+		}
+	}
+	return append(append([]Node{}, cycle[first:]...), cycle[:first]...)
+}
+
+// Calls visit with every `$ref` of the value and the JSON Pointer to the object that holds it.
+func eachReference(pointer string, value interface{}, visit func(pointer string, ref string)) {
+	switch node := value.(type) {
+	case map[string]interface{}:
+		if ref, isString := node["$ref"].(string); isString {
+			visit(pointer, ref)
+		}
+		for _, key := range sortedKeys(node) {
+			eachReference(jsonpointer.Append(pointer, key), node[key], visit)
+		}
+	case []interface{}:
+		for index, item := range node {
+			eachReference(jsonpointer.Append(pointer, strconv.Itoa(index)), item, visit)
+		}
+	}
+}
+
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,73 @@
+package references_test
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/references"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindCycles(t *testing.T) {
+	Assert := assert.New(t)
+	_, testsFile, _, _ := runtime.Caller(0)
+	dir := filepath.Join(filepath.Dir(testsFile), "..", "tests", "references", "cycles")
+	specURI, otherURI := document.URI(filepath.Join(dir, "spec.yaml")), document.URI(filepath.Join(dir, "other.yaml"))
+
+	findCycles := func(pointer string) []string {
+		cycles := []string{}
+		for _, cycle := range references.FindCycles(document.NewStore(), specURI, pointer) {
+			cycles = append(cycles, references.FormatCycle(specURI, cycle))
+		}
+		return cycles
+	}
+
+	t.Run("Recursive schemas can be satisfied", func(t *testing.T) {
+		Assert.Empty(findCycles("/components/schemas/Node"))
+	})
+
+	t.Run("A schema referencing itself", func(t *testing.T) {
+		Assert.Equal([]string{"#/components/schemas/Self -> #/components/schemas/Self"}, findCycles("/components/schemas/Self"))
+	})
+
+	t.Run("Schemas referencing each other through allOf", func(t *testing.T) {
+		Assert.Equal([]string{
+			"#/components/schemas/A -> #/components/schemas/B -> #/components/schemas/B/allOf/1 -> #/components/schemas/A",
+		}, findCycles("/components/schemas/B"))
+	})
+
+	t.Run("Cycles between documents", func(t *testing.T) {
+		Assert.Equal([]string{
+			"other.yaml#/Loop -> other.yaml#/Loop/oneOf/0 -> #/components/schemas/Remote -> other.yaml#/Loop",
+		}, findCycles("/components/schemas/Remote"))
+	})
+
+	t.Run("Cycles reachable through properties", func(t *testing.T) {
+		Assert.Equal([]string{"#/components/schemas/Self -> #/components/schemas/Self"}, findCycles("/components/schemas/Leaf"))
+	})
+
+	t.Run("Every cycle of the document is found once", func(t *testing.T) {
+		// WHEN
+		store := document.NewStore()
+		specCycles := references.FindDocumentCycles(store, specURI)
+		otherCycles := references.FindDocumentCycles(store, otherURI)
+
+		// THEN
+		Assert.Len(specCycles, 2)
+		Assert.Equal("/components/schemas/A", specCycles[0][0].Pointer)
+		Assert.Equal("/components/schemas/Self", specCycles[1][0].Pointer)
+		Assert.Len(otherCycles, 1)
+		Assert.Equal(references.Node{URI: otherURI, Pointer: "/Loop"}, otherCycles[0][0])
+	})
+
+	t.Run("The error describes the first cycle", func(t *testing.T) {
+		// WHEN
+		err := references.CheckCycles(document.NewStore(), specURI, "/components/schemas/A")
+
+		// THEN
+		Assert.EqualError(err, "the schema has a reference cycle that can't be satisfied: #/components/schemas/A -> #/components/schemas/B -> #/components/schemas/B/allOf/1 -> #/components/schemas/A")
+		Assert.Nil(references.CheckCycles(document.NewStore(), specURI, "/components/schemas/Node"))
+	})
+}
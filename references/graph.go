@@ -0,0 +1,244 @@
+package references
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/jsonpointer"
+)
+
+// Sections of reusable components in OpenAPI 3 (also in documents that only hold shared components)
+var componentSections = []string{"callbacks", "examples", "headers", "links", "parameters", "pathItems", "requestBodies", "responses", "schemas"}
+
+// Sections of reusable definitions in Swagger 2.0
+var swaggerSections = []string{"definitions", "parameters", "responses"}
+
+// Sections of sub-schemas in standalone JSON Schema documents
+var schemaSections = []string{"$defs", "definitions"}
+
+// A reference between two values: the object at From holds the `$ref` (or the discriminator mapping) pointing to To
+type Edge struct {
+	From Node
+	To   Node
+}
+
+// The references between the values of the documents
+type Graph struct {
+	// Canonical URIs of all documents of the graph, sorted
+	Documents []string
+	// References that can be resolved, sorted by the source and the target
+	Edges []Edge
+}
+
+// Builds the graph of references of the documents (identified by canonical URIs).
+// Documents that can't be loaded and references that can't be resolved (or are remote) are skipped,
+// documents referenced by the given ones aren't added to the graph.
+func BuildGraph(store *document.Store, uris []string) Graph {
+	graph := Graph{Documents: []string{}, Edges: []Edge{}}
+	for _, uri := range uris {
+		root, err := store.Get(uri, "")
+		if err != nil {
+			continue
+		}
+		graph.Documents = append(graph.Documents, uri)
+		eachGraphReference("", root, func(pointer string, ref string) {
+			if isRemote(ref) {
+				return
+			}
+			refURI, refPointer, err := document.Locate(uri, ref)
+			if err != nil {
+				return
+			}
+			if _, err := store.Get(refURI, refPointer); err != nil {
+				return
+			}
+			graph.Edges = append(graph.Edges, Edge{Node{uri, pointer}, Node{refURI, refPointer}})
+		})
+	}
+	sort.Strings(graph.Documents)
+	sort.SliceStable(graph.Edges, func(i, j int) bool {
+		if graph.Edges[i].From != graph.Edges[j].From {
+			return less(graph.Edges[i].From, graph.Edges[j].From)
+		}
+		return less(graph.Edges[i].To, graph.Edges[j].To)
+	})
+	return graph
+}
+
+// Returns the references of the value at the node and all values nested in it.
+func (graph Graph) EdgesWithin(node Node) []Edge {
+	edges := []Edge{}
+	for _, edge := range graph.Edges {
+		if edge.From.URI == node.URI && Contains(node.Pointer, edge.From.Pointer) {
+			edges = append(edges, edge)
+		}
+	}
+	return edges
+}
+
+// Returns the nodes reachable from the roots: the roots themselves and the targets of the references
+// held by reachable values (or values nested in them).
+func (graph Graph) Reachable(roots []Node) []Node {
+	reached := map[Node]bool{}
+	queue := []Node{}
+	reach := func(node Node) {
+		if !reached[node] {
+			reached[node] = true
+			queue = append(queue, node)
+		}
+	}
+	for _, root := range roots {
+		reach(root)
+	}
+	for len(queue) > 0 {
+		node := queue[0]
+		queue = queue[1:]
+		for _, edge := range graph.EdgesWithin(node) {
+			reach(edge.To)
+		}
+	}
+
+	nodes := make([]Node, 0, len(reached))
+	for node := range reached {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return less(nodes[i], nodes[j]) })
+	return nodes
+}
+
+// Determines if the value at the pointer is nested in the value at the parent pointer (or it's the same value).
+func Contains(parent string, pointer string) bool {
+	return pointer == parent || strings.HasPrefix(pointer, parent+"/")
+}
+
+func less(a Node, b Node) bool {
+	if a.URI != b.URI {
+		return a.URI < b.URI
+	}
+	return a.Pointer < b.Pointer
+}
+
+// Calls visit with every `$ref` of the value, and every schema named by discriminator mappings,
+// with the JSON Pointer to the object that holds it.
+// Mapping values are either references or names of schemas of `components/schemas`.
+func eachGraphReference(pointer string, value interface{}, visit func(pointer string, ref string)) {
+	eachReference(pointer, value, visit)
+	eachDiscriminator(pointer, value, func(pointer string, discriminator map[string]interface{}) {
+		mapping, _ := discriminator["mapping"].(map[string]interface{})
+		for _, key := range sortedKeys(mapping) {
+			target, _ := mapping[key].(string)
+			if len(target) == 0 {
+				continue
+			}
+			if !strings.ContainsAny(target, "#/.") {
+				target = "#" + jsonpointer.Append("/components/schemas", target)
+			}
+			visit(pointer, target)
+		}
+	})
+}
+
+// Calls visit with every `discriminator` object of the value and the JSON Pointer to the schema that holds it.
+func eachDiscriminator(pointer string, value interface{}, visit func(pointer string, discriminator map[string]interface{})) {
+	switch node := value.(type) {
+	case map[string]interface{}:
+		if discriminator, isObject := node["discriminator"].(map[string]interface{}); isObject {
+			visit(pointer, discriminator)
+		}
+		for _, key := range sortedKeys(node) {
+			eachDiscriminator(jsonpointer.Append(pointer, key), node[key], visit)
+		}
+	case []interface{}:
+		for index, item := range node {
+			eachDiscriminator(jsonpointer.Append(pointer, strconv.Itoa(index)), item, visit)
+		}
+	}
+}
+
+// Returns the components of the document, sorted by the section and the name.
+func components(uri string, root map[string]interface{}) []Node {
+	nodes := []Node{}
+	addSection := func(sectionPointer string, section interface{}) {
+		entries, _ := section.(map[string]interface{})
+		for _, name := range sortedKeys(entries) {
+			nodes = append(nodes, Node{uri, jsonpointer.Append(sectionPointer, name)})
+		}
+	}
+
+	componentsObject, _ := root["components"].(map[string]interface{})
+	for _, section := range componentSections {
+		addSection(jsonpointer.Append("/components", section), componentsObject[section])
+	}
+	sections := schemaSections
+	if root["swagger"] != nil {
+		sections = swaggerSections
+	}
+	for _, section := range sections {
+		addSection(jsonpointer.Append("", section), root[section])
+	}
+	return nodes
+}
+
+// Returns the components of the document identified by the canonical URI (e.g. `#/components/schemas/User`,
+// `#/definitions/User`), sorted by the section and the name.
+func Components(store *document.Store, uri string) []Node {
+	root, _ := store.Get(uri, "")
+	object, _ := root.(map[string]interface{})
+	return components(uri, object)
+}
+
+// Keywords of schemas that tell a standalone schema from a document holding schemas by their names
+var schemaRootKeywords = []string{"$id", "$ref", "$schema", "allOf", "anyOf", "enum", "items", "oneOf", "properties", "type"}
+
+// Returns the node of the component, the path item (e.g. `#/paths/~1users`) or the document that holds the value of the node.
+// Values of documents without the `swagger`/`openapi` field that hold schemas by their names (e.g. `User: {type: object}`)
+// are held by the top-level entries.
+func Owner(store *document.Store, node Node) Node {
+	tokens, err := jsonpointer.Parse(node.Pointer)
+	if err != nil || len(tokens) == 0 {
+		return Node{node.URI, ""}
+	}
+	root, _ := store.Get(node.URI, "")
+	object, _ := root.(map[string]interface{})
+	if object["openapi"] == nil && object["swagger"] == nil && object["components"] == nil && !hasAny(object, schemaRootKeywords) && !contains(schemaSections, tokens[0]) {
+		return Node{node.URI, jsonpointer.Format(tokens[:1])}
+	}
+	if len(tokens) < 2 {
+		return Node{node.URI, ""}
+	}
+	sections := schemaSections
+	if object["swagger"] != nil {
+		sections = swaggerSections
+	}
+	owner := func(length int) Node {
+		return Node{node.URI, jsonpointer.Format(tokens[:length])}
+	}
+
+	switch {
+	case tokens[0] == "components" && len(tokens) >= 3 && contains(componentSections, tokens[1]):
+		return owner(3)
+	case tokens[0] == "paths" || tokens[0] == "webhooks" || contains(sections, tokens[0]):
+		return owner(2)
+	}
+	return Node{node.URI, ""}
+}
+
+func contains(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+	return false
+}
+
+func hasAny(object map[string]interface{}, keys []string) bool {
+	for _, key := range keys {
+		if _, found := object[key]; found {
+			return true
+		}
+	}
+	return false
+}
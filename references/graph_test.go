@@ -0,0 +1,65 @@
+package references_test
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/references"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildGraph(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	_, testsFile, _, _ := runtime.Caller(0)
+	dir := filepath.Join(filepath.Dir(testsFile), "..", "tests", "references", "graph")
+	specURI, schemasURI := document.URI(filepath.Join(dir, "spec.yaml")), document.URI(filepath.Join(dir, "schemas.yaml"))
+	store := document.NewStore()
+
+	// WHEN
+	graph := references.BuildGraph(store, []string{specURI, schemasURI})
+
+	// THEN
+	Assert.Equal([]string{schemasURI, specURI}, graph.Documents)
+	Assert.Equal([]references.Edge{
+		{From: references.Node{URI: schemasURI, Pointer: "/Cat/properties/toy"}, To: references.Node{URI: schemasURI, Pointer: "/Toy"}},
+		{From: references.Node{URI: specURI, Pointer: "/components/schemas/Pet"}, To: references.Node{URI: schemasURI, Pointer: "/Cat"}},
+		{From: references.Node{URI: specURI, Pointer: "/components/schemas/Pet"}, To: references.Node{URI: specURI, Pointer: "/components/schemas/Dog"}},
+		{From: references.Node{URI: specURI, Pointer: "/components/schemas/Pet/oneOf/0"}, To: references.Node{URI: schemasURI, Pointer: "/Cat"}},
+		{From: references.Node{URI: specURI, Pointer: "/paths/~1pets/get/responses/200/content/application~1json/schema"}, To: references.Node{URI: specURI, Pointer: "/components/schemas/Pet"}},
+	}, graph.Edges, "References that can't be resolved and remote ones are skipped, discriminator mappings are references")
+
+	// WHEN
+	reached := graph.Reachable([]references.Node{{URI: specURI, Pointer: "/paths"}})
+
+	// THEN
+	Assert.Equal([]references.Node{
+		{URI: schemasURI, Pointer: "/Cat"},
+		{URI: schemasURI, Pointer: "/Toy"},
+		{URI: specURI, Pointer: "/components/schemas/Dog"},
+		{URI: specURI, Pointer: "/components/schemas/Pet"},
+		{URI: specURI, Pointer: "/paths"},
+	}, reached)
+}
+
+func TestOwner(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	_, testsFile, _, _ := runtime.Caller(0)
+	dir := filepath.Join(filepath.Dir(testsFile), "..", "tests", "references", "graph")
+	specURI, schemasURI := document.URI(filepath.Join(dir, "spec.yaml")), document.URI(filepath.Join(dir, "schemas.yaml"))
+	store := document.NewStore()
+	owner := func(uri string, pointer string) string {
+		return references.Owner(store, references.Node{URI: uri, Pointer: pointer}).Pointer
+	}
+
+	// THEN
+	Assert.Equal("/components/schemas/Pet", owner(specURI, "/components/schemas/Pet/oneOf/0"))
+	Assert.Equal("/paths/~1pets", owner(specURI, "/paths/~1pets/get/responses"))
+	Assert.Equal("", owner(specURI, "/info/title"))
+	Assert.Equal("/Cat", owner(schemasURI, "/Cat/properties/toy"), "Top-level entries of documents holding schemas by names are owners")
+}
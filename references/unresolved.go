@@ -0,0 +1,197 @@
+package references
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/jsonpointer"
+)
+
+// Name of the check reported with every `$ref` that can't be resolved
+const UnresolvedRule = "unresolved-ref"
+
+// Describes the check in reports that list rules
+const UnresolvedRuleDescription = "Every `$ref` must point to an existing document and value."
+
+// Store informations about a `$ref` that can't be resolved.
+// Pointer points to the `$ref` value, Hint is the nearest existing path relative to the document (if found).
+type Unresolved struct {
+	Pointer string
+	Ref     string
+	Hint    string
+	Err     error
+}
+
+// Formats the error and the hint.
+func (unresolved Unresolved) Error() string {
+	message := fmt.Sprintf("can't resolve the reference %q: %s", unresolved.Ref, unresolved.Err)
+	if len(unresolved.Hint) > 0 {
+		message += fmt.Sprintf(" (nearest existing path: %s)", unresolved.Hint)
+	}
+	return message
+}
+
+// Finds every `$ref` of the document (identified by the canonical URI) that points to a missing file or value.
+// Remote references (e.g. `https://...`) aren't checked. Results are returned in the order of the document.
+func FindUnresolved(store *document.Store, uri string) []Unresolved {
+	root, err := store.Get(uri, "")
+	if err != nil {
+		return nil
+	}
+	unresolved := []Unresolved{}
+	eachReference("", root, func(pointer string, ref string) {
+		if isRemote(ref) {
+			return
+		}
+		refPointer := jsonpointer.Append(pointer, "$ref")
+		refURI, targetPointer, err := document.Locate(uri, ref)
+		if err != nil {
+			unresolved = append(unresolved, Unresolved{Pointer: refPointer, Ref: ref, Err: err})
+			return
+		}
+		if _, err := store.Load(refURI); err != nil {
+			unresolved = append(unresolved, Unresolved{Pointer: refPointer, Ref: ref, Err: documentError(err), Hint: nearestFile(uri, refURI)})
+			return
+		}
+		if _, err := store.Get(refURI, targetPointer); err != nil {
+			unresolved = append(unresolved, Unresolved{Pointer: refPointer, Ref: ref, Err: pointerError(err), Hint: nearestValue(store, uri, refURI, err)})
+		}
+	})
+	return unresolved
+}
+
+// Determines if the reference points to a document outside of the file system, e.g. `https://...` or `urn:...`.
+func isRemote(ref string) bool {
+	scheme := strings.SplitN(ref, ":", 2)
+	// Windows drive letters aren't schemes
+	return len(scheme) == 2 && len(scheme[0]) > 1 && !strings.ContainsAny(scheme[0], "/#.") && scheme[0] != "file"
+}
+
+// Describes why the referenced document can't be loaded.
+func documentError(err error) error {
+	if os.IsNotExist(err) {
+		return fmt.Errorf("the file doesn't exist")
+	}
+	if _, isParseError := err.(*document.ParseError); isParseError {
+		return fmt.Errorf("the file can't be parsed: %s", err)
+	}
+	return err
+}
+
+// Describes why the referenced value can't be found, without the path of the document.
+func pointerError(err error) error {
+	if notFoundError, isNotFound := unwrapNotFound(err); isNotFound {
+		return notFoundError
+	}
+	return err
+}
+
+func unwrapNotFound(err error) (*jsonpointer.NotFoundError, bool) {
+	var notFoundError *jsonpointer.NotFoundError
+	isNotFound := errors.As(err, &notFoundError)
+	return notFoundError, isNotFound
+}
+
+// Returns the nearest existing value for the missing pointer: the key of the deepest existing value
+// with the most similar name, or the deepest existing value itself.
+func nearestValue(store *document.Store, uri string, refURI string, err error) string {
+	notFoundError, isNotFound := unwrapNotFound(err)
+	if !isNotFound {
+		return ""
+	}
+	nearest := Node{refURI, notFoundError.Parent}
+	parent, _ := store.Get(refURI, notFoundError.Parent)
+	if object, isObject := parent.(map[string]interface{}); isObject {
+		if key, found := mostSimilar(notFoundError.Token, sortedKeys(object)); found {
+			nearest.Pointer = jsonpointer.Append(notFoundError.Parent, key)
+		}
+	}
+	return nearest.Relative(uri)
+}
+
+// Returns the nearest existing file for the missing one: a document in the same directory with the most similar name,
+// or the deepest existing directory.
+func nearestFile(uri string, refURI string) string {
+	missingPath := document.FilePath(refURI)
+	baseDir := filepath.Dir(document.FilePath(uri))
+	relative := func(path string) string {
+		if relativePath, err := filepath.Rel(baseDir, path); err == nil {
+			return filepath.ToSlash(relativePath)
+		}
+		return path
+	}
+
+	dir := filepath.Dir(missingPath)
+	if files, err := ioutil.ReadDir(dir); err == nil {
+		names := []string{}
+		for _, file := range files {
+			if !file.IsDir() && document.IsSupported(file.Name()) {
+				names = append(names, file.Name())
+			}
+		}
+		if name, found := mostSimilar(filepath.Base(missingPath), names); found {
+			return relative(filepath.Join(dir, name))
+		}
+	}
+	for {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			return relative(dir) + "/"
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// Returns the candidate most similar to the name (by the edit distance, case insensitive).
+// Candidates that differ in more than a third of the name aren't similar.
+func mostSimilar(name string, candidates []string) (string, bool) {
+	sort.Strings(candidates)
+	best, bestDistance := "", len(name)/3+1
+	for _, candidate := range candidates {
+		if distance := editDistance(strings.ToLower(name), strings.ToLower(candidate)); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best, len(best) > 0
+}
+
+// Returns the Levenshtein distance of the strings.
+func editDistance(a string, b string) int {
+	first, second := []rune(a), []rune(b)
+	previous := make([]int, len(second)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(first); i++ {
+		current := make([]int, len(second)+1)
+		current[0] = i
+		for j := 1; j <= len(second); j++ {
+			cost := 1
+			if first[i-1] == second[j-1] {
+				cost = 0
+			}
+			current[j] = minimum(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(second)]
+}
+
+func minimum(values ...int) int {
+	lowest := values[0]
+	for _, value := range values[1:] {
+		if value < lowest {
+			lowest = value
+		}
+	}
+	return lowest
+}
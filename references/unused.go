@@ -0,0 +1,110 @@
+package references
+
+import (
+	"regexp"
+
+	"github.com/clearcodehq/openapi-linter/document"
+)
+
+// Name of the check reported with every component that no operation reaches
+const UnusedComponentRule = "unused-component"
+
+// Describes the check in reports that list rules
+const UnusedComponentRuleDescription = "Every component and definition must be reachable from an operation."
+
+// Name of the check reported with every document that no operation reaches
+const OrphanedFileRule = "orphaned-file"
+
+// Describes the check in reports that list rules
+const OrphanedFileRuleDescription = "Every document must be an OpenAPI document or be reachable from an operation."
+
+var allOfItem = regexp.MustCompile(`/allOf/[0-9]+$`)
+
+// Finds the components (e.g. `#/components/schemas/User`, `#/definitions/User`) and documents that no operation
+// of the OpenAPI documents reaches through `$ref`s (and discriminator mappings), among the documents identified by canonical URIs.
+// Public components and documents (Pointer is empty) are used as if an operation reached them, as well as everything they reference.
+// Documents are returned with an empty Pointer, components of documents that aren't reached at all aren't returned.
+func FindUnused(store *document.Store, uris []string, public func(node Node) bool) []Node {
+	graph := BuildGraph(store, uris)
+
+	roots := []Node{}
+	candidates := map[string][]Node{}
+	isOpenAPI := map[string]bool{}
+	for _, uri := range graph.Documents {
+		root, _ := store.Get(uri, "")
+		object, _ := root.(map[string]interface{})
+		isOpenAPI[uri] = object["openapi"] != nil || object["swagger"] != nil
+		if isOpenAPI[uri] {
+			roots = append(roots, Node{uri, "/paths"}, Node{uri, "/webhooks"})
+		}
+		candidates[uri] = components(uri, object)
+		for _, node := range append([]Node{{uri, ""}}, candidates[uri]...) {
+			if public != nil && public(node) {
+				roots = append(roots, node)
+			}
+		}
+	}
+
+	reached := reachWithDiscriminators(store, graph, roots)
+	unused := []Node{}
+	for _, uri := range graph.Documents {
+		if !isOpenAPI[uri] && !isUsed(reached, Node{uri, ""}) {
+			unused = append(unused, Node{uri, ""})
+			continue
+		}
+		for _, component := range candidates[uri] {
+			if !isUsed(reached, component) {
+				unused = append(unused, component)
+			}
+		}
+	}
+	return unused
+}
+
+// Returns the nodes reachable from the roots. Schemas that extend (by `allOf`) a reached schema with a discriminator
+// are reached too, they're the implicit variants of the polymorphic schema.
+func reachWithDiscriminators(store *document.Store, graph Graph, roots []Node) []Node {
+	for {
+		reached := graph.Reachable(roots)
+		isReached := map[Node]bool{}
+		for _, node := range reached {
+			isReached[node] = true
+		}
+
+		variants := []Node{}
+		for _, edge := range graph.Edges {
+			if !allOfItem.MatchString(edge.From.Pointer) || !isReached[edge.To] {
+				continue
+			}
+			variant := Node{edge.From.URI, allOfItem.ReplaceAllString(edge.From.Pointer, "")}
+			if isReached[variant] {
+				continue
+			}
+			parent, _ := store.Get(edge.To.URI, edge.To.Pointer)
+			if object, isObject := parent.(map[string]interface{}); isObject && object["discriminator"] != nil {
+				variants = append(variants, variant)
+			}
+		}
+		if len(variants) == 0 {
+			return reached
+		}
+		roots = append(roots, variants...)
+	}
+}
+
+// Determines if a reached node is the node, is nested in it or holds it.
+// The root of a document doesn't hold its components, a standalone schema doesn't use all of its definitions.
+func isUsed(reached []Node, node Node) bool {
+	for _, reachedNode := range reached {
+		if reachedNode.URI != node.URI {
+			continue
+		}
+		if len(node.Pointer) == 0 || Contains(node.Pointer, reachedNode.Pointer) {
+			return true
+		}
+		if len(reachedNode.Pointer) > 0 && Contains(reachedNode.Pointer, node.Pointer) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,140 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Store informations about a single check performed by the linter, whether it has failed or not.
+type Check struct {
+	File    string
+	Pointer string
+	Rule    string
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// Writes a JUnit XML report.
+// Every scanned file becomes a test suite and every check becomes a test case, files without checks have empty test suites.
+// Findings that don't belong to any check (e.g. files that can't be parsed) are reported as separate failed test cases.
+// Test suites are ordered by the file name.
+func WriteJUnit(w io.Writer, files []string, checks []Check, findings []Finding) error {
+	findings = Sort(findings)
+	checks = append([]Check{}, checks...)
+	sort.SliceStable(checks, func(i, j int) bool {
+		return checks[i].File < checks[j].File
+	})
+
+	suites := []*junitTestSuite{}
+	suiteIndexes := map[string]int{}
+	getSuite := func(file string) *junitTestSuite {
+		if index, exists := suiteIndexes[file]; exists {
+			return suites[index]
+		}
+		suiteIndexes[file] = len(suites)
+		suites = append(suites, &junitTestSuite{Name: file})
+		return suites[len(suites)-1]
+	}
+	for _, file := range files {
+		getSuite(file)
+	}
+
+	failures := map[Check][]Finding{}
+	for _, finding := range findings {
+		check := Check{finding.File, finding.Pointer, finding.Rule}
+		failures[check] = append(failures[check], finding)
+	}
+
+	seen := map[Check]bool{}
+	addTestCase := func(check Check) {
+		if seen[check] {
+			return
+		}
+		seen[check] = true
+
+		suite := getSuite(check.File)
+		testCase := junitTestCase{Name: testCaseName(check), ClassName: check.File}
+		if checkFindings := failures[check]; len(checkFindings) > 0 {
+			messages := []string{}
+			for _, finding := range checkFindings {
+				messages = append(messages, finding.Message)
+			}
+			testCase.Failure = &junitFailure{
+				Message: firstLine(checkFindings[0].Message),
+				Type:    check.Rule,
+				Text:    strings.Join(messages, "\n"),
+			}
+			suite.Failures++
+		}
+		suite.Tests++
+		suite.Cases = append(suite.Cases, testCase)
+	}
+
+	for _, check := range checks {
+		addTestCase(check)
+	}
+	for _, finding := range findings {
+		addTestCase(Check{finding.File, finding.Pointer, finding.Rule})
+	}
+	sort.SliceStable(suites, func(i, j int) bool {
+		return suites[i].Name < suites[j].Name
+	})
+
+	report := junitTestSuites{Name: toolName, Suites: []junitTestSuite{}}
+	for _, suite := range suites {
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Suites = append(report.Suites, *suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func testCaseName(check Check) string {
+	pointer := check.Pointer
+	if len(pointer) == 0 {
+		pointer = "(root)"
+	}
+	return fmt.Sprintf("%s %s", check.Rule, pointer)
+}
+
+func firstLine(message string) string {
+	return strings.SplitN(message, "\n", 2)[0]
+}
@@ -0,0 +1,85 @@
+package report_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/clearcodehq/openapi-linter/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	Assert := assert.New(t)
+
+	t.Run("No checks", func(t *testing.T) {
+		// GIVEN
+		output := bytes.Buffer{}
+
+		// WHEN
+		err := report.WriteJUnit(&output, nil, nil, nil)
+
+		// THEN
+		Assert.Nil(err)
+		Assert.Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="openapi-linter" tests="0" failures="0"></testsuites>
+`, output.String())
+	})
+
+	t.Run("Passed and failed checks", func(t *testing.T) {
+		// GIVEN
+		output := bytes.Buffer{}
+		checks := []report.Check{
+			{File: "a.json", Pointer: "", Rule: "valid-schema"},
+			{File: "a.json", Pointer: "/definitions/user", Rule: "valid-schema"},
+			{File: "b.json", Pointer: "/paths", Rule: "valid-example"},
+		}
+		findings := []report.Finding{
+			{File: "a.json", Pointer: "/definitions/user", Rule: "valid-schema", Message: "first\nsecond", Severity: report.SeverityError},
+			{File: "a.json", Pointer: "/definitions/user", Rule: "valid-schema", Message: "third", Severity: report.SeverityError},
+			{File: "c.json", Rule: "valid-example", Message: "can't unmarshal contents", Severity: report.SeverityError},
+		}
+
+		// WHEN
+		err := report.WriteJUnit(&output, []string{"a.json", "b.json", "c.json"}, checks, findings)
+
+		// THEN
+		Assert.Nil(err)
+		Assert.Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="openapi-linter" tests="4" failures="2">
+  <testsuite name="a.json" tests="2" failures="1">
+    <testcase name="valid-schema (root)" classname="a.json"></testcase>
+    <testcase name="valid-schema /definitions/user" classname="a.json">
+      <failure message="first" type="valid-schema">first&#xA;second&#xA;third</failure>
+    </testcase>
+  </testsuite>
+  <testsuite name="b.json" tests="1" failures="0">
+    <testcase name="valid-example /paths" classname="b.json"></testcase>
+  </testsuite>
+  <testsuite name="c.json" tests="1" failures="1">
+    <testcase name="valid-example (root)" classname="c.json">
+      <failure message="can&#39;t unmarshal contents" type="valid-example">can&#39;t unmarshal contents</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, output.String())
+	})
+	t.Run("Files without checks", func(t *testing.T) {
+		// GIVEN
+		output := bytes.Buffer{}
+		checks := []report.Check{{File: "spec.yaml", Rule: "valid-spec"}}
+
+		// WHEN
+		err := report.WriteJUnit(&output, []string{"spec.yaml", "schema.json"}, checks, nil)
+
+		// THEN
+		Assert.Nil(err)
+		Assert.Equal(`<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="openapi-linter" tests="1" failures="0">
+  <testsuite name="schema.json" tests="0" failures="0"></testsuite>
+  <testsuite name="spec.yaml" tests="1" failures="0">
+    <testcase name="valid-spec (root)" classname="spec.yaml"></testcase>
+  </testsuite>
+</testsuites>
+`, output.String())
+	})
+}
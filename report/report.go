@@ -0,0 +1,150 @@
+// Structured representation of the linter findings and the writers for supported output formats.
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Severities of findings, from the most severe
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+	SeverityHint    = "hint"
+)
+
+var Severities = []string{SeverityError, SeverityWarning, SeverityInfo, SeverityHint}
+
+// Supported output formats.
+const (
+	FormatText  = "text"
+	FormatJSON  = "json"
+	FormatSARIF = "sarif"
+	FormatJUnit = "junit"
+)
+
+var Formats = []string{FormatText, FormatJSON, FormatSARIF, FormatJUnit}
+
+// Store informations about a single problem found by the linter.
+type Finding struct {
+	File     string `json:"file"`
+	Pointer  string `json:"pointer"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Rule     string `json:"rule"`
+	Message  string `json:"message"`
+	Severity string `json:"severity"`
+}
+
+// A finding silenced by a suppression in the document, it doesn't fail the run.
+type Suppressed struct {
+	Finding
+	// JSON Pointer to the node holding the suppression
+	SuppressedBy string `json:"suppressedBy"`
+	Reason       string `json:"reason"`
+}
+
+// Returns the location of the finding as file:line:column.
+// The line and column are skipped if the position is unknown.
+func (finding Finding) Location() string {
+	if finding.Line == 0 {
+		return finding.File
+	}
+	if finding.Column == 0 {
+		return fmt.Sprintf("%s:%d", finding.File, finding.Line)
+	}
+	return fmt.Sprintf("%s:%d:%d", finding.File, finding.Line, finding.Column)
+}
+
+// Determines if the finding a should be reported before the finding b.
+// Findings are ordered by the file, the position in the file and the rule.
+func Less(a Finding, b Finding) bool {
+	if a.File != b.File {
+		return a.File < b.File
+	}
+	if a.Line != b.Line {
+		return a.Line < b.Line
+	}
+	if a.Column != b.Column {
+		return a.Column < b.Column
+	}
+	if a.Rule != b.Rule {
+		return a.Rule < b.Rule
+	}
+	return a.Pointer < b.Pointer
+}
+
+// Returns a sorted copy of findings, the order of equal findings is preserved.
+func Sort(findings []Finding) []Finding {
+	sorted := append([]Finding{}, findings...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return Less(sorted[i], sorted[j])
+	})
+	return sorted
+}
+
+// The document written by the JSON output format.
+type jsonReport struct {
+	Findings   []Finding    `json:"findings"`
+	Suppressed []Suppressed `json:"suppressed,omitempty"`
+}
+
+// Returns a sorted copy of suppressed findings, ordered like findings.
+func SortSuppressed(suppressed []Suppressed) []Suppressed {
+	sorted := append([]Suppressed{}, suppressed...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return Less(sorted[i].Finding, sorted[j].Finding)
+	})
+	return sorted
+}
+
+// Checks the format is one of supported output formats.
+func ValidateFormat(format string) error {
+	for _, supported := range Formats {
+		if format == supported {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported output format: %s", format)
+}
+
+// Checks the severity is one of supported severities.
+func ValidateSeverity(severity string) error {
+	for _, supported := range Severities {
+		if severity == supported {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported severity: %s", severity)
+}
+
+// Returns the rank of the severity, the most severe has the lowest rank. Unknown severities are ranked as errors.
+func SeverityRank(severity string) int {
+	for rank, supported := range Severities {
+		if severity == supported {
+			return rank
+		}
+	}
+	return 0
+}
+
+// Determines if the severity is the threshold or more severe, e.g. an error is at least a warning.
+func AtLeast(severity string, threshold string) bool {
+	return SeverityRank(severity) <= SeverityRank(threshold)
+}
+
+// Writes findings as a single JSON document.
+func WriteJSON(w io.Writer, findings []Finding) error {
+	return WriteJSONWithSuppressed(w, findings, nil)
+}
+
+// Writes findings as a single JSON document, suppressed findings are listed in a separate section.
+func WriteJSONWithSuppressed(w io.Writer, findings []Finding, suppressed []Suppressed) error {
+	findings = Sort(findings)
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(jsonReport{findings, SortSuppressed(suppressed)})
+}
@@ -0,0 +1,139 @@
+package report_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/clearcodehq/openapi-linter/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteJSON(t *testing.T) {
+	Assert := assert.New(t)
+
+	t.Run("No findings", func(t *testing.T) {
+		// GIVEN
+		output := bytes.Buffer{}
+
+		// WHEN
+		err := report.WriteJSON(&output, nil)
+
+		// THEN
+		Assert.Nil(err)
+		Assert.JSONEq(`{"findings": []}`, output.String())
+	})
+
+	t.Run("Single finding", func(t *testing.T) {
+		// GIVEN
+		output := bytes.Buffer{}
+		findings := []report.Finding{
+			{
+				File:     "spec.json",
+				Pointer:  "/definitions/user",
+				Rule:     "valid-schema",
+				Message:  "properties.id.type: Must validate at least one schema (anyOf)",
+				Severity: report.SeverityError,
+			},
+		}
+
+		// WHEN
+		err := report.WriteJSON(&output, findings)
+
+		// THEN
+		Assert.Nil(err)
+		Assert.JSONEq(`{"findings": [{
+			"file": "spec.json",
+			"pointer": "/definitions/user",
+			"rule": "valid-schema",
+			"message": "properties.id.type: Must validate at least one schema (anyOf)",
+			"severity": "error"
+		}]}`, output.String())
+	})
+
+	t.Run("Suppressed finding", func(t *testing.T) {
+		// GIVEN
+		output := bytes.Buffer{}
+		suppressed := []report.Suppressed{
+			{
+				Finding: report.Finding{
+					File:     "spec.yaml",
+					Pointer:  "/components/schemas/Legacy",
+					Line:     12,
+					Column:   5,
+					Rule:     "unused-component",
+					Message:  "unused",
+					Severity: report.SeverityWarning,
+				},
+				SuppressedBy: "/components",
+				Reason:       "kept for old clients",
+			},
+		}
+
+		// WHEN
+		err := report.WriteJSONWithSuppressed(&output, nil, suppressed)
+
+		// THEN
+		Assert.Nil(err)
+		Assert.JSONEq(`{"findings": [], "suppressed": [{
+			"file": "spec.yaml",
+			"pointer": "/components/schemas/Legacy",
+			"line": 12,
+			"column": 5,
+			"rule": "unused-component",
+			"message": "unused",
+			"severity": "warning",
+			"suppressedBy": "/components",
+			"reason": "kept for old clients"
+		}]}`, output.String())
+	})
+}
+
+func TestValidateFormat(t *testing.T) {
+	Assert := assert.New(t)
+
+	Assert.Nil(report.ValidateFormat("json"))
+	Assert.NotNil(report.ValidateFormat("xml"))
+}
+
+func TestFindingLocation(t *testing.T) {
+	Assert := assert.New(t)
+
+	Assert.Equal("spec.json", report.Finding{File: "spec.json"}.Location())
+	Assert.Equal("spec.json:3:14", report.Finding{File: "spec.json", Line: 3, Column: 14}.Location())
+	Assert.Equal("spec.yaml:3", report.Finding{File: "spec.yaml", Line: 3}.Location())
+}
+
+func TestSort(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	findings := []report.Finding{
+		{File: "b.json", Line: 1, Column: 1, Rule: "valid-schema", Message: "1"},
+		{File: "a.json", Line: 10, Column: 1, Rule: "valid-schema", Message: "2"},
+		{File: "a.json", Line: 2, Column: 5, Rule: "valid-schema", Message: "3"},
+		{File: "a.json", Line: 2, Column: 5, Rule: "valid-example", Message: "4"},
+		{File: "a.json", Line: 2, Column: 3, Rule: "valid-schema", Message: "5"},
+		{File: "a.json", Line: 2, Column: 5, Rule: "valid-schema", Message: "6"},
+	}
+
+	// WHEN
+	sorted := report.Sort(findings)
+
+	// THEN
+	messages := []string{}
+	for _, finding := range sorted {
+		messages = append(messages, finding.Message)
+	}
+	Assert.Equal([]string{"5", "4", "3", "6", "2", "1"}, messages)
+	Assert.Equal("1", findings[0].Message, "The original slice shouldn't be modified.")
+}
+
+func TestAtLeast(t *testing.T) {
+	Assert := assert.New(t)
+
+	Assert.True(report.AtLeast(report.SeverityError, report.SeverityWarning))
+	Assert.True(report.AtLeast(report.SeverityWarning, report.SeverityWarning))
+	Assert.False(report.AtLeast(report.SeverityInfo, report.SeverityWarning))
+	Assert.True(report.AtLeast(report.SeverityHint, report.SeverityHint))
+	Assert.False(report.AtLeast(report.SeverityHint, report.SeverityError))
+}
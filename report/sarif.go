@@ -0,0 +1,202 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+
+	"github.com/clearcodehq/openapi-linter/version"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName     = "openapi-linter"
+	toolURI      = "https://github.com/clearcodehq/openapi-linter"
+	// Base of relative artifact locations, the directory the linter runs in (e.g. the root of the repository)
+	sarifSourceRoot = "%SRCROOT%"
+)
+
+// Describes a check that produces findings.
+type Rule struct {
+	ID          string
+	Description string
+	// Default severity of findings, SeverityError if it's empty
+	Severity string
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string                `json:"name"`
+	Version        string                `json:"version"`
+	InformationURI string                `json:"informationUri"`
+	Rules          []sarifRuleDescriptor `json:"rules"`
+}
+
+type sarifRuleDescriptor struct {
+	ID                   string             `json:"id"`
+	ShortDescription     sarifMessage       `json:"shortDescription"`
+	DefaultConfiguration sarifConfiguration `json:"defaultConfiguration"`
+}
+
+type sarifConfiguration struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	RuleIndex int             `json:"ruleIndex"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations"`
+	// Suppressed results are kept in the log, viewers hide them
+	Suppressions []sarifSuppression `json:"suppressions,omitempty"`
+}
+
+type sarifSuppression struct {
+	Kind          string `json:"kind"`
+	Justification string `json:"justification,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+// Returns the location of the file. Relative paths are relative URIs against the sarifSourceRoot,
+// absolute paths are `file://` URIs. Characters that aren't allowed in URIs are escaped, e.g. spaces as %20.
+func sarifArtifact(path string) sarifArtifactLocation {
+	slashPath := filepath.ToSlash(path)
+	if !filepath.IsAbs(path) {
+		relativeURI := url.URL{Path: slashPath}
+		return sarifArtifactLocation{URI: relativeURI.String(), URIBaseID: sarifSourceRoot}
+	}
+	// Windows, e.g. file:///C:/specs/spec.json
+	if !strings.HasPrefix(slashPath, "/") {
+		slashPath = "/" + slashPath
+	}
+	fileURI := url.URL{Scheme: "file", Path: slashPath}
+	return sarifArtifactLocation{URI: fileURI.String()}
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+}
+
+// Translates the finding severity to the SARIF result level.
+func sarifLevel(severity string) string {
+	switch severity {
+	case SeverityError, "":
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	}
+	return "note"
+}
+
+// Writes findings as a SARIF 2.1.0 log with a single run.
+// Rules are written as the rule descriptors of the tool and referenced by results.
+func WriteSARIF(w io.Writer, rules []Rule, findings []Finding) error {
+	return WriteSARIFWithSuppressed(w, rules, findings, nil)
+}
+
+// Writes findings as a SARIF 2.1.0 log, suppressed findings are written as results with an in-source suppression.
+func WriteSARIFWithSuppressed(w io.Writer, rules []Rule, findings []Finding, suppressed []Suppressed) error {
+	driver := sarifDriver{
+		Name:           toolName,
+		Version:        version.Version,
+		InformationURI: toolURI,
+		Rules:          []sarifRuleDescriptor{},
+	}
+	ruleIndexes := map[string]int{}
+	addRule := func(rule Rule) {
+		if _, exists := ruleIndexes[rule.ID]; exists {
+			return
+		}
+		ruleIndexes[rule.ID] = len(driver.Rules)
+		driver.Rules = append(driver.Rules, sarifRuleDescriptor{
+			ID:                   rule.ID,
+			ShortDescription:     sarifMessage{rule.Description},
+			DefaultConfiguration: sarifConfiguration{sarifLevel(rule.Severity)},
+		})
+	}
+	for _, rule := range rules {
+		addRule(rule)
+	}
+
+	results := []sarifResult{}
+	addResult := func(finding Finding) *sarifResult {
+		addRule(Rule{ID: finding.Rule, Description: finding.Rule})
+
+		location := sarifLocation{
+			PhysicalLocation: sarifPhysicalLocation{
+				ArtifactLocation: sarifArtifact(finding.File),
+			},
+		}
+		if finding.Line > 0 {
+			location.PhysicalLocation.Region = &sarifRegion{finding.Line, finding.Column}
+		}
+		if len(finding.Pointer) > 0 {
+			location.LogicalLocations = []sarifLogicalLocation{{finding.Pointer}}
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    finding.Rule,
+			RuleIndex: ruleIndexes[finding.Rule],
+			Level:     sarifLevel(finding.Severity),
+			Message:   sarifMessage{finding.Message},
+			Locations: []sarifLocation{location},
+		})
+		return &results[len(results)-1]
+	}
+	for _, finding := range Sort(findings) {
+		addResult(finding)
+	}
+	for _, suppressedFinding := range SortSuppressed(suppressed) {
+		result := addResult(suppressedFinding.Finding)
+		result.Suppressions = []sarifSuppression{{"inSource", suppressedFinding.Reason}}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{{sarifTool{driver}, results}},
+	})
+}
@@ -0,0 +1,139 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/clearcodehq/openapi-linter/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteSARIF(t *testing.T) {
+	Assert := assert.New(t)
+	rules := []report.Rule{
+		{ID: "valid-schema", Description: "Objects must be valid JSON Schemas."},
+	}
+
+	writeSARIFHelper := func(findings []report.Finding) map[string]interface{} {
+		output := bytes.Buffer{}
+		Assert.Nil(report.WriteSARIF(&output, rules, findings))
+
+		log := map[string]interface{}{}
+		Assert.Nil(json.Unmarshal(output.Bytes(), &log))
+		return log
+	}
+
+	t.Run("No findings", func(t *testing.T) {
+		// WHEN
+		log := writeSARIFHelper(nil)
+
+		// THEN
+		Assert.Equal("2.1.0", log["version"])
+		run := log["runs"].([]interface{})[0].(map[string]interface{})
+		Assert.Equal([]interface{}{}, run["results"])
+
+		driver := run["tool"].(map[string]interface{})["driver"].(map[string]interface{})
+		Assert.Equal("openapi-linter", driver["name"])
+		Assert.Equal([]interface{}{
+			map[string]interface{}{
+				"id":                   "valid-schema",
+				"shortDescription":     map[string]interface{}{"text": "Objects must be valid JSON Schemas."},
+				"defaultConfiguration": map[string]interface{}{"level": "error"},
+			},
+		}, driver["rules"])
+	})
+
+	t.Run("Findings are written as results", func(t *testing.T) {
+		// GIVEN
+		findings := []report.Finding{
+			{
+				File:     filepath.Join("specs", "spec.json"),
+				Pointer:  "/definitions/user",
+				Line:     3,
+				Column:   14,
+				Rule:     "valid-example",
+				Message:  "(root): id is required",
+				Severity: report.SeverityError,
+			},
+		}
+
+		// WHEN
+		log := writeSARIFHelper(findings)
+
+		// THEN
+		run := log["runs"].([]interface{})[0].(map[string]interface{})
+		driver := run["tool"].(map[string]interface{})["driver"].(map[string]interface{})
+		Assert.Len(driver["rules"], 2)
+
+		result := run["results"].([]interface{})[0].(map[string]interface{})
+		Assert.Equal("valid-example", result["ruleId"])
+		Assert.Equal(float64(1), result["ruleIndex"])
+		Assert.Equal("error", result["level"])
+		Assert.Equal(map[string]interface{}{"text": "(root): id is required"}, result["message"])
+		Assert.Equal([]interface{}{
+			map[string]interface{}{
+				"physicalLocation": map[string]interface{}{
+					"artifactLocation": map[string]interface{}{"uri": "specs/spec.json", "uriBaseId": "%SRCROOT%"},
+					"region":           map[string]interface{}{"startLine": float64(3), "startColumn": float64(14)},
+				},
+				"logicalLocations": []interface{}{
+					map[string]interface{}{"fullyQualifiedName": "/definitions/user"},
+				},
+			},
+		}, result["locations"])
+	})
+
+	t.Run("Paths are written as URIs", func(t *testing.T) {
+		// GIVEN
+		absolutePath, _ := filepath.Abs(filepath.Join("specs", "user schema.yaml"))
+		findings := []report.Finding{
+			{File: filepath.Join("specs", "user schema#1.yaml"), Rule: "valid-spec", Message: "invalid"},
+			{File: absolutePath, Rule: "valid-spec", Message: "invalid"},
+		}
+
+		// WHEN
+		log := writeSARIFHelper(findings)
+
+		// THEN
+		artifactLocations := []interface{}{}
+		for _, result := range log["runs"].([]interface{})[0].(map[string]interface{})["results"].([]interface{}) {
+			location := result.(map[string]interface{})["locations"].([]interface{})[0].(map[string]interface{})
+			artifactLocations = append(artifactLocations, location["physicalLocation"].(map[string]interface{})["artifactLocation"])
+		}
+		Assert.Equal([]interface{}{
+			map[string]interface{}{"uri": "file://" + strings.ReplaceAll(filepath.ToSlash(filepath.Dir(absolutePath)), " ", "%20") + "/user%20schema.yaml"},
+			map[string]interface{}{"uri": "specs/user%20schema%231.yaml", "uriBaseId": "%SRCROOT%"},
+		}, artifactLocations, "Relative paths are relative to the source root, absolute paths are file URIs")
+	})
+}
+
+func TestWriteSARIFLevels(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	rules := []report.Rule{{ID: "unused-component", Description: "Components must be used.", Severity: report.SeverityWarning}}
+	findings := []report.Finding{}
+	for _, severity := range report.Severities {
+		findings = append(findings, report.Finding{File: "spec.yaml", Rule: "unused-component", Message: severity, Severity: severity})
+	}
+	output := bytes.Buffer{}
+
+	// WHEN
+	Assert.Nil(report.WriteSARIF(&output, rules, findings))
+
+	// THEN
+	log := map[string]interface{}{}
+	Assert.Nil(json.Unmarshal(output.Bytes(), &log))
+	run := log["runs"].([]interface{})[0].(map[string]interface{})
+	driver := run["tool"].(map[string]interface{})["driver"].(map[string]interface{})
+	rule := driver["rules"].([]interface{})[0].(map[string]interface{})
+	Assert.Equal(map[string]interface{}{"level": "warning"}, rule["defaultConfiguration"])
+	levels := []string{}
+	for _, result := range run["results"].([]interface{}) {
+		levels = append(levels, result.(map[string]interface{})["level"].(string))
+	}
+	Assert.Equal([]string{"error", "warning", "note", "note"}, levels, "Infos and hints are notes")
+}
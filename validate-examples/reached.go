@@ -0,0 +1,90 @@
+package validate_examples
+
+import (
+	"strings"
+
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/references"
+	"github.com/clearcodehq/openapi-linter/validate"
+)
+
+// Documents whose root is referenced by the OpenAPI documents, by their canonical URIs.
+// Tells the roots of files that aren't OpenAPI documents apart: a schema or an example payload.
+type ReachedFiles struct {
+	// Roots referenced as schemas, e.g. `schema: {$ref: user.json}`, also through `$ref`s of the referenced schemas
+	Schemas map[string]bool
+	// Roots referenced as example values, e.g. `example: {$ref: user.json}` or `externalValue: user.json`
+	Examples map[string]bool
+}
+
+// Finds the documents whose root is referenced by the schemas and the examples of the OpenAPI documents
+// (identified by canonical URIs). References that can't be resolved are skipped.
+func FindReachedFiles(store *document.Store, uris []string) ReachedFiles {
+	reached := ReachedFiles{Schemas: map[string]bool{}, Examples: map[string]bool{}}
+	visited := map[references.Node]bool{}
+	var visitSchema func(uri string, pointer string, schema map[string]interface{})
+	visitSchema = func(uri string, pointer string, schema map[string]interface{}) {
+		validate.EachSubSchema(pointer, schema, func(pointer string, subSchema map[string]interface{}) {
+			refURI, refPointer, referenced := resolveObject(store, uri, getReference(subSchema))
+			if referenced == nil || visited[references.Node{URI: refURI, Pointer: refPointer}] {
+				return
+			}
+			visited[references.Node{URI: refURI, Pointer: refPointer}] = true
+			if len(refPointer) == 0 {
+				reached.Schemas[refURI] = true
+			}
+			visitSchema(refURI, refPointer, referenced)
+		})
+	}
+
+	for _, uri := range uris {
+		root, err := store.Get(uri, "")
+		object, _ := root.(map[string]interface{})
+		if err != nil || len(validate.OpenAPIVersion(object)) == 0 {
+			continue
+		}
+		validate.FindOpenAPISchemas(object, func(pointer string, schema map[string]interface{}) {
+			visitSchema(uri, pointer, schema)
+		})
+		walkDocumentExamples(object, func(pointer string, example Example, err error) {
+			examplePath := example.examplePath
+			if externalValue, isString := example.exampleObject["externalValue"].(string); isString && !strings.Contains(externalValue, "://") {
+				examplePath = externalValue
+			}
+			if len(examplePath) == 0 {
+				return
+			}
+			if refURI, refPointer, err := document.Locate(uri, examplePath); err == nil && len(refPointer) == 0 {
+				reached.Examples[refURI] = true
+			}
+		})
+	}
+	return reached
+}
+
+// Determines if the root of the document (identified by the canonical URI), which isn't an OpenAPI document, is a schema:
+// it's referenced as a schema, or it isn't referenced as an example value and looks like a schema, see validate.IsSchema.
+func (reached ReachedFiles) IsSchemaRoot(uri string, root map[string]interface{}) bool {
+	if reached.Schemas[uri] {
+		return true
+	}
+	return !reached.Examples[uri] && validate.IsSchema(root)
+}
+
+// Returns the canonical URI, the JSON Pointer and the object referenced by the `$ref` relative to the document,
+// or a nil object if the reference is empty, remote or can't be resolved to an object.
+func resolveObject(store *document.Store, uri string, ref string) (string, string, map[string]interface{}) {
+	if len(ref) == 0 || strings.Contains(ref, "://") {
+		return "", "", nil
+	}
+	refURI, refPointer, err := document.Locate(uri, ref)
+	if err != nil {
+		return "", "", nil
+	}
+	value, err := store.Get(refURI, refPointer)
+	object, _ := value.(map[string]interface{})
+	if err != nil {
+		return "", "", nil
+	}
+	return refURI, refPointer, object
+}
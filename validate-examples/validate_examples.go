@@ -1,227 +1,558 @@
 package validate_examples
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"sort"
 	"strconv"
 
-	"github.com/PaesslerAG/jsonpath"
-	"github.com/bmatcuk/doublestar"
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/jsonpointer"
+	"github.com/clearcodehq/openapi-linter/references"
+	"github.com/clearcodehq/openapi-linter/report"
+	"github.com/clearcodehq/openapi-linter/validate"
 	"github.com/xeipuuv/gojsonschema"
 
 	"io/ioutil"
+	"net/http"
 	"path/filepath"
 	"strings"
 )
 
+// An example and its schema, referenced by `$ref` or inline.
+// The inline schema and example are used if the respective path is empty.
+// Entries of the `examples` map have a name, their value is described by the Example Object.
 type Example struct {
-	schemaPath  string
-	examplePath string
+	schemaPath    string
+	examplePath   string
+	schema        map[string]interface{}
+	example       interface{}
+	name          string
+	exampleObject map[string]interface{}
+}
+
+// Name of the check reported with every example error
+const Rule = "valid-example"
+
+// Describes the check in reports that list rules
+const RuleDescription = "Examples must match the schema defined next to them."
+
+// Store informations about an example that couldn't be validated or doesn't match its schema
+// Rule names the check that found the error, the package level Rule is used if it's empty.
+type ExampleError struct {
+	FilePath    string
+	JsonPointer string
+	Line        int
+	Column      int
+	Err         error
+	Rule        string
+}
+
+func (exampleError ExampleError) Error() string {
+	return exampleError.Err.Error()
+}
+
+// Converts the example error to the report finding.
+// Files that can't be parsed are reported with the document.ParseErrorRule.
+func (exampleError ExampleError) Finding() report.Finding {
+	rule := Rule
+	if len(exampleError.Rule) > 0 {
+		rule = exampleError.Rule
+	}
+	if _, isParseError := exampleError.Err.(*document.ParseError); isParseError {
+		rule = document.ParseErrorRule
+	}
+	return report.Finding{
+		File:     exampleError.FilePath,
+		Pointer:  exampleError.JsonPointer,
+		Line:     exampleError.Line,
+		Column:   exampleError.Column,
+		Rule:     rule,
+		Message:  exampleError.Err.Error(),
+		Severity: report.SeverityError,
+	}
 }
 
 const arrayError = "Invalid type. Expected: object, given: array"
 
 // Find all examples and their respective schemas.
-// Unfortunately, `jsonpath` seems not to implement JsonPath filters on recursive traversal queries.
-// This introduces an layer of complexity related to the dynamic traversal of the JSON nodes.
-// The function can be simplified if you'll find a lib that implements queries like:
-// $..[?(@.examples)] <- find all nodes that have the examples property
-// Or if you find any library that has a decent implementation of JSON Walker
-// Related: https://github.com/PaesslerAG/jsonpath/issues/24
 func FindExamples(jsonObject map[string]interface{}, cb func(Example, error)) {
-	jsonNodes, _ := jsonpath.Get("$..*", jsonObject)
-	nodes := sortNodes(jsonNodes.([]interface{}))
+	walkDocumentExamples(jsonObject, func(pointer string, example Example, err error) {
+		cb(example, err)
+	})
+}
 
-	for _, node := range nodes {
+// Visits all examples of the document, and the entries of `components/examples`.
+// Swagger 2.0 documents use `examples` maps of raw values by the MIME type, instead of Example Objects.
+func walkDocumentExamples(root interface{}, cb func(string, Example, error)) {
+	object, _ := root.(map[string]interface{})
+	rawExamples := object["swagger"] == "2.0"
+	walkExamples("", root, rawExamples, cb)
 
-		schema, _ := jsonpath.Get(`$["schema"]["$ref"]`, node)
-		example, _ := jsonpath.Get(`$["example"]["$ref"]`, node)
+	components, _ := object["components"].(map[string]interface{})
+	componentExamples, _ := components["examples"].(map[string]interface{})
+	findExampleObjects("/components/examples", componentExamples, Example{}, cb)
+}
 
-		schemaStr, schemaOk := schema.(string)
-		exampleStr, exampleOk := example.(string)
+// Recursively visits all objects and arrays in a deterministic order.
+// Calls cb with the JSON Pointer of every node that holds an example.
+func walkExamples(pointer string, node interface{}, rawExamples bool, cb func(string, Example, error)) {
+	switch value := node.(type) {
+	case map[string]interface{}:
+		findExample(pointer, value, rawExamples, cb)
 
-		if !schemaOk || !exampleOk {
-			if exampleOk {
-				cb(Example{}, fmt.Errorf("can't find schema of the example"))
-			}
-			continue
+		for _, key := range sortedKeys(value) {
+			walkExamples(jsonpointer.Append(pointer, key), value[key], rawExamples, cb)
+		}
+	case []interface{}:
+		for index, item := range value {
+			walkExamples(jsonpointer.Append(pointer, strconv.Itoa(index)), item, rawExamples, cb)
 		}
+	}
+}
 
-		cb(Example{schemaStr, exampleStr}, nil)
+func sortedKeys(object map[string]interface{}) []string {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
+	return keys
 }
 
-// Deterministic sort json nodes.
-// Return only nodes that are map[string]interface{}.
-func sortNodes(jsonNodes []interface{}) []map[string]interface{} {
+// Checks if the node holds an example (or a map of examples) and a schema.
+// Both can be referenced by `$ref` or inline, inline examples can be of any type.
+func findExample(pointer string, node map[string]interface{}, rawExamples bool, cb func(string, Example, error)) {
+	example, hasExample := node["example"]
+	examples, hasExamples := node["examples"].(map[string]interface{})
+	if !hasExample && !hasExamples {
+		return
+	}
+	exampleRef := getReference(example)
 
-	nodes := map[string]interface{}{}
-	keys := []string(nil)
+	schema, hasSchema := node["schema"]
+	if !hasSchema {
+		if len(exampleRef) > 0 {
+			cb(pointer, Example{}, fmt.Errorf("Can't find the schema of the example."))
+		}
+		return
+	}
 
-	for _, node := range jsonNodes {
-		key := fmt.Sprint(node)
-		keys = append(keys, key)
-		nodes[key] = node
+	schemaObject, schemaOk := schema.(map[string]interface{})
+	if !schemaOk {
+		cb(pointer, Example{}, fmt.Errorf("Can't cast the schema object to map[string]."))
+		return
 	}
 
-	sort.Strings(keys)
+	found := Example{schemaPath: getReference(schemaObject)}
+	if len(found.schemaPath) == 0 {
+		found.schema = schemaObject
+	}
+
+	if hasExample {
+		singular := found
+		singular.examplePath = exampleRef
+		if len(singular.examplePath) == 0 {
+			singular.example = example
+		}
+		cb(pointer, singular, nil)
+	}
 
-	sortedNodes := []map[string]interface{}(nil)
-	for _, key := range keys {
-		if node, ok := nodes[key].(map[string]interface{}); ok {
-			sortedNodes = append(sortedNodes, node)
+	examplesPointer := jsonpointer.Append(pointer, "examples")
+	if rawExamples {
+		for _, name := range sortedKeys(examples) {
+			entry := found
+			entry.name, entry.example = name, examples[name]
+			cb(jsonpointer.Append(examplesPointer, name), entry, nil)
 		}
+		return
 	}
+	findExampleObjects(examplesPointer, examples, found, cb)
+}
 
-	return sortedNodes
+// Calls cb with every entry of the map of Example Objects, the schema is copied from the example.
+func findExampleObjects(pointer string, examples map[string]interface{}, example Example, cb func(string, Example, error)) {
+	for _, name := range sortedKeys(examples) {
+		entryPointer := jsonpointer.Append(pointer, name)
+		exampleObject, isObject := examples[name].(map[string]interface{})
+		if !isObject {
+			cb(entryPointer, Example{}, fmt.Errorf("example %q: Can't cast the example object to map[string].", name))
+			continue
+		}
+		entry := example
+		entry.name, entry.exampleObject = name, exampleObject
+		cb(entryPointer, entry, nil)
+	}
+}
+
+// Returns the `$ref` of the object, or an empty string if the value isn't a reference.
+func getReference(value interface{}) string {
+	object, _ := value.(map[string]interface{})
+	ref, _ := object["$ref"].(string)
+	return ref
 }
 
 // Read JSON object from a file and Unmarshal it as a generic map.
 func GetObjectFromFile(filePath string) (map[string]interface{}, error) {
-	fileObject := map[string]interface{}{}
+	fileDocument, err := GetDocumentFromFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	fileObject, isObject := fileDocument.Root.(map[string]interface{})
+	if !isObject {
+		return nil, fmt.Errorf("can't unmarshal contents: %s: the root value isn't an object", filePath)
+	}
+	return fileObject, nil
+}
+
+// Read JSON or YAML document from a file together with positions of all its values.
+// Syntax errors are returned as *document.ParseError.
+func GetDocumentFromFile(filePath string) (*document.Document, error) {
 	jsonBytes, err := ioutil.ReadFile(filePath)
 	if err != nil {
 		return nil, fmt.Errorf("can't read the file:%s:%s", filePath, err)
 	}
 
-	err = json.Unmarshal(jsonBytes, &fileObject)
-
-	if err != nil {
-		return nil, fmt.Errorf("can't unmarshal contents: %s: %+v", filePath, err)
+	fileDocument, err := document.Parse(filePath, jsonBytes)
+	if parseError, isParseError := err.(*document.ParseError); isParseError {
+		parseError.Err = fmt.Errorf("can't unmarshal contents: %s: %+v", filePath, parseError.Err)
+		return nil, parseError
 	}
-	return fileObject, nil
+	return fileDocument, err
 }
 
-// Generator to retrieve contents of next json files and pass them to a scan function.
-func ScanJSONFiles(mainPath string, scanFunction func(string)) {
-	jsonFiles, _ := doublestar.Glob(mainPath + "/**/*.json")
+// Generator to retrieve contents of next JSON and YAML files and pass them to a scan function.
+// Files are found like validate.FindJsonFiles finds them, partial files (e.g. `user.partial.json`, `user.partial.yaml`) are skipped.
+// Returns an error if the directory can't be read.
+func ScanJSONFiles(mainPath string, scanFunction func(string)) error {
+	jsonFiles, err := validate.FindJsonFiles(mainPath)
+	if err != nil {
+		return err
+	}
 	for _, jsonFile := range jsonFiles {
-		if strings.Contains(jsonFile, ".partial.json") {
-			continue
-		}
-		file, err := os.Stat(jsonFile)
-
-		if err != nil || !file.IsDir() {
+		if !isPartialFile(jsonFile) {
 			scanFunction(jsonFile)
 		}
 	}
+	return nil
 }
 
+// Validates all examples found in JSON files within given directory and returns plain errors.
 func ScanForExamples(rootPath string) []error {
 	var errors []error
-	ScanJSONFiles(rootPath, func(jsonPath string) {
-		jsonObject, err := GetObjectFromFile(jsonPath)
+	exampleErrors, _ := ScanForExampleErrors(rootPath)
+	for _, exampleError := range exampleErrors {
+		errors = append(errors, exampleError.Err)
+	}
+	return errors
+}
+
+// Validates all examples found in JSON files within given directory.
+// Every error is annotated with the file and the JSON Pointer of the node that holds the example.
+// Returns errors sorted by the file, position and rule, and the list of all checked examples.
+// A directory that can't be read is reported like a directory without examples, see ScanDirForExampleErrors.
+func ScanForExampleErrors(rootPath string) ([]ExampleError, []report.Check) {
+	errors, checks, _ := ScanDirForExampleErrors(rootPath)
+	return errors, checks
+}
+
+// Works like ScanForExampleErrors and returns an error if the directory can't be read.
+func ScanDirForExampleErrors(rootPath string) ([]ExampleError, []report.Check, error) {
+	return ScanStoreForExampleErrors(document.NewStore(), rootPath)
+}
+
+// Works like ScanDirForExampleErrors, documents are loaded from the store.
+func ScanStoreForExampleErrors(store *document.Store, rootPath string) ([]ExampleError, []report.Check, error) {
+	var errors []ExampleError
+	checks := []report.Check{}
+	// Every document is read and parsed once, references between documents are resolved in memory
+	jsonDocuments, uris := []*document.Document{}, []string{}
+	err := ScanJSONFiles(rootPath, func(jsonPath string) {
+		jsonDocument, err := GetDocumentFromFile(jsonPath)
 
 		if err != nil {
-			errors = append(errors, err)
+			fileError := validate.FileError(jsonPath, err)
+			errors = append(errors, ExampleError{FilePath: jsonPath, Line: fileError.Line, Column: fileError.Column, Err: err})
 			return
 		}
-		FindExamples(jsonObject, func(example Example, parseErr error) {
-			if example.examplePath == "" || example.schemaPath == "" {
-				errors = append(errors, parseErr)
-				return
-			}
+		store.Add(jsonDocument)
+		jsonDocuments, uris = append(jsonDocuments, jsonDocument), append(uris, document.URI(jsonPath))
+	})
+	// all documents are loaded first, so the roots of files referenced as schemas or examples are known
+	reached := FindReachedFiles(store, uris)
+	for _, jsonDocument := range jsonDocuments {
+		errors = append(errors, ScanDocumentForExampleErrors(store, jsonDocument, reached, &checks)...)
+	}
+	sort.SliceStable(errors, func(i, j int) bool {
+		return report.Less(errors[i].Finding(), errors[j].Finding())
+	})
+	return errors, checks, err
+}
 
-			examplePath := filepath.Join(filepath.Dir(jsonPath), example.examplePath)
-			schemaPath := filepath.Join(filepath.Dir(jsonPath), example.schemaPath)
+// Validates all examples of the parsed document and the values embedded in its schemas, in the order of the document.
+// Referenced documents are loaded from the store, reached tells if the root of a file that isn't an OpenAPI document is a schema.
+// Checked examples are recorded in checks.
+func ScanDocumentForExampleErrors(store *document.Store, jsonDocument *document.Document, reached ReachedFiles, checks *[]report.Check) []ExampleError {
+	errors := []ExampleError{}
+	jsonPath := jsonDocument.Path
+	walkDocumentExamples(jsonDocument.Root, func(pointer string, example Example, parseErr error) {
+		*checks = append(*checks, report.Check{File: jsonPath, Pointer: pointer, Rule: Rule})
+		position := jsonDocument.Position(pointer)
+		addError := func(err error) {
+			errors = append(errors, ExampleError{jsonPath, pointer, position.Line, position.Column, err, ""})
+		}
 
-			// Windows
-			examplePath = strings.ReplaceAll(examplePath, `\`, `/`)
-			schemaPath = strings.ReplaceAll(schemaPath, `\`, `/`)
+		if parseErr != nil {
+			addError(parseErr)
+			return
+		}
+		for _, err := range validateExample(store, jsonPath, pointer, example) {
+			addError(err)
+		}
+	})
+	for _, validationError := range findSchemaValueErrors(store, jsonPath, jsonDocument.Root, reached, checks) {
+		position := jsonDocument.Position(validationError.Pointer())
+		errors = append(errors, ExampleError{jsonPath, validationError.Pointer(), position.Line, position.Column, validationError.Err, validationError.Rule})
+	}
+	return errors
+}
 
-			if parseErr != nil {
-				errors = append(errors, parseErr)
-				return
-			}
+// Validates the values embedded in schemas (`example`, `examples` and `default`) against the schemas that hold them.
+// Schemas of OpenAPI documents are found at their schema locations, the root of other files is validated
+// only if it's a schema, see ReachedFiles.IsSchemaRoot.
+func findSchemaValueErrors(store *document.Store, filePath string, root interface{}, reached ReachedFiles, checks *[]report.Check) []validate.ValidationError {
+	object, isObject := root.(map[string]interface{})
+	if !isObject {
+		return nil
+	}
+	if len(validate.OpenAPIVersion(object)) == 0 {
+		if !reached.IsSchemaRoot(document.URI(filePath), object) {
+			return nil
+		}
+		return validate.ValidateSchemaValues(store, filePath, "", object, checks)
+	}
+	validationErrors := []validate.ValidationError{}
+	validate.FindOpenAPISchemas(object, func(pointer string, schema map[string]interface{}) {
+		validationErrors = append(validationErrors, validate.ValidateSchemaValues(store, filePath, pointer, schema, checks)...)
+	})
+	return validationErrors
+}
 
-			exampleLoader, exampleLoaderErr := GetReferenceLoader(examplePath)
-			exampleSchemaLoader, exampleSchemaLoaderErr := GetReferenceLoader(schemaPath)
+// Validates the example found at the pointer of the spec file against its schema.
+// Referenced files are resolved relative to the spec, inline schemas are resolved within the spec, so they can use `$ref`s too.
+// Arrays of examples that don't match the schema are validated item by item.
+// Example Objects without a schema (e.g. `components/examples`) are only checked to be resolvable.
+// Errors of named examples are prefixed with the example name.
+func validateExample(store *document.Store, specPath string, pointer string, example Example) []error {
+	errors := validateExampleValue(store, specPath, pointer, example)
+	if len(example.name) > 0 {
+		for index, err := range errors {
+			errors[index] = fmt.Errorf("example %q: %s", example.name, err)
+		}
+	}
+	return errors
+}
 
-			if exampleLoaderErr != nil {
-				errors = append(errors, fmt.Errorf("[example=%s, schema=%s] %s", example.examplePath, example.schemaPath, exampleLoaderErr))
-				return
-			}
+// Returned for Example Objects that reference each other in a cycle, the cycle starts from its first node
+type exampleCycleError struct {
+	cycle []references.Node
+}
 
-			if exampleSchemaLoaderErr != nil {
-				errors = append(errors, fmt.Errorf("[example=%s, schema=%s] %s", example.examplePath, example.schemaPath, exampleSchemaLoaderErr))
-				return
-			}
+func (err *exampleCycleError) Error() string {
+	return fmt.Sprintf("the example references itself: %s", references.FormatCycle(err.cycle[0].URI, err.cycle))
+}
 
-			result, valErr := gojsonschema.Validate(*exampleSchemaLoader, *exampleLoader)
-			if valErr != nil {
-				errors = append(errors, fmt.Errorf("%s: %s", example.examplePath, valErr))
-				return
-			}
+func validateExampleValue(store *document.Store, specPath string, pointer string, example Example) []error {
+	errors := []error{}
+	exampleName, schemaName := example.examplePath, example.schemaPath
+
+	var exampleLoader *gojsonschema.JSONLoader
+	var exampleLoaderErr error
+	switch {
+	case len(example.examplePath) > 0:
+		exampleLoader, exampleLoaderErr = getReferenceLoader(store, resolvePath(specPath, example.examplePath))
+	case example.exampleObject != nil:
+		exampleLoader, exampleName, exampleLoaderErr = getExampleObjectLoader(store, specPath, "#"+pointer, example.exampleObject, []references.Node{{URI: document.URI(specPath), Pointer: pointer}})
+	default:
+		exampleName = "#" + jsonpointer.Append(pointer, "example")
+		if len(example.name) > 0 {
+			exampleName = "#" + pointer
+		}
+		inlineLoader := gojsonschema.NewGoLoader(example.example)
+		exampleLoader = &inlineLoader
+	}
+	if len(example.schemaPath) == 0 && example.schema != nil {
+		schemaName = "#" + jsonpointer.Append(strings.TrimSuffix(pointer, "/examples/"+jsonpointer.Escape(example.name)), "schema")
+	}
 
-			// Handle example array.
-			if len(result.Errors()) > 0 && strings.Contains(result.Errors()[0].String(), arrayError) {
-
-				exampleLoaders, err := unpackArray(*exampleLoader)
-				if err != nil {
-					errors = append(errors, fmt.Errorf("%s: %s", example.examplePath, err))
-					return
-				}
-
-				for _, exampleLoader := range exampleLoaders {
-					result, valErr := gojsonschema.Validate(*exampleSchemaLoader, exampleLoader)
-					if valErr != nil {
-						errors = append(errors, fmt.Errorf("%s: %s", example.examplePath, valErr))
-						return
-					}
-
-					for _, err := range result.Errors() {
-						errors = append(errors, fmt.Errorf("%s: %s", example.examplePath, err.String()))
-					}
-				}
+	// a cycle is reported once, by the example at its first node, examples referencing the cycle can't be validated
+	if cycleErr, isCycle := exampleLoaderErr.(*exampleCycleError); isCycle && cycleErr.cycle[0] != (references.Node{URI: document.URI(specPath), Pointer: pointer}) {
+		return errors
+	}
+	if exampleLoaderErr != nil && len(schemaName) == 0 {
+		return append(errors, fmt.Errorf("%s: %s", exampleName, exampleLoaderErr))
+	}
+	if exampleLoaderErr != nil {
+		return append(errors, fmt.Errorf("[example=%s, schema=%s] %s", exampleName, schemaName, exampleLoaderErr))
+	}
+	if exampleLoader == nil || len(schemaName) == 0 {
+		return errors
+	}
+	// gojsonschema never finishes the validation against schemas with such cycles
+	if schemaURI, schemaPointer, err := document.Locate(specPath, schemaName); err == nil {
+		if cycleErr := references.CheckCycles(store, schemaURI, schemaPointer); cycleErr != nil {
+			return append(errors, fmt.Errorf("[example=%s, schema=%s] %s", exampleName, schemaName, cycleErr))
+		}
+	}
+	// `nullable` of OpenAPI 3.0 schemas allows null examples
+	exampleSchemaLoader := getSchemaLoader(validate.SchemaFileSystem(store, specPath), resolvePath(specPath, schemaName))
+
+	result, valErr := gojsonschema.Validate(exampleSchemaLoader, *exampleLoader)
+	if valErr != nil {
+		return append(errors, fmt.Errorf("%s: %s", exampleName, valErr))
+	}
+
+	// Handle example array.
+	if len(result.Errors()) > 0 && strings.Contains(result.Errors()[0].String(), arrayError) {
+
+		exampleLoaders, err := unpackArray(*exampleLoader)
+		if err != nil {
+			return append(errors, fmt.Errorf("%s: %s", exampleName, err))
+		}
+
+		for _, exampleLoader := range exampleLoaders {
+			result, valErr := gojsonschema.Validate(exampleSchemaLoader, exampleLoader)
+			if valErr != nil {
+				return append(errors, fmt.Errorf("%s: %s", exampleName, valErr))
 			}
 
 			for _, err := range result.Errors() {
-				if !strings.Contains(result.Errors()[0].String(), arrayError) {
-					errors = append(errors, fmt.Errorf("%s: %s", example.examplePath, err.String()))
-				}
+				errors = append(errors, fmt.Errorf("%s: %s", exampleName, err.String()))
 			}
-		})
-	})
+		}
+		return errors
+	}
+
+	for _, err := range result.Errors() {
+		errors = append(errors, fmt.Errorf("%s: %s", exampleName, err.String()))
+	}
 	return errors
 }
 
-// `gojsonschema` doesn't handle reference paths that point to specific fields like:
-// file://aaa/bbb.json#definitions/example/something
-// It will return the root object of that JSON file, completely ignoring the part after #
-// As a workaround, this function loads that file, calls a JsonPath query to get the referenced object.
-// Also, gojsonschema is not able to resolve schema references if a JSON object is not saved on disk.
-// *Side effects*
-// If arefPath contains the link to an object, it will generate a file on your file system.
-// Related: https://github.com/xeipuuv/gojsonschema/issues/262
-func GetReferenceLoader(refPath string) (*gojsonschema.JSONLoader, error) {
+// Returns the loader of the value described by the Example Object found in the file at the reference.
+// `$ref`s to other Example Objects are followed, `externalValue`s are resolved relative to the file that holds the Example Object.
+// Remote `externalValue`s (e.g. `https://...`) aren't loaded, the returned loader is nil.
+// Returns the name of the value for error messages.
+// The path lists the Example Objects followed so far, Example Objects referencing each other in a cycle are reported.
+func getExampleObjectLoader(store *document.Store, filePath string, reference string, exampleObject map[string]interface{}, path []references.Node) (*gojsonschema.JSONLoader, string, error) {
+	if ref := getReference(exampleObject); len(ref) > 0 {
+		refURI, refPointer, err := document.Locate(filePath, ref)
+		if err != nil {
+			return nil, ref, fmt.Errorf("can't load the example: %s", err)
+		}
+		refNode := references.Node{URI: refURI, Pointer: refPointer}
+		for index, node := range path {
+			if node == refNode {
+				return nil, ref, &exampleCycleError{references.RotateCycle(path[index:])}
+			}
+		}
 
-	simpleLoader := gojsonschema.NewReferenceLoader(fmt.Sprintf("file://%s", refPath))
-	if !strings.Contains(refPath, "#") {
-		return &simpleLoader, nil
+		referenced, err := store.Get(refURI, refPointer)
+		if err != nil {
+			return nil, ref, fmt.Errorf("can't load the example: %s", err)
+		}
+		referencedObject, isObject := referenced.(map[string]interface{})
+		if !isObject {
+			return nil, ref, fmt.Errorf("the referenced example isn't an object: %s", ref)
+		}
+		return getExampleObjectLoader(store, refURI, ref, referencedObject, append(path, refNode))
 	}
 
-	pathParts := strings.Split(refPath, "#")
-	filePath := pathParts[0]
-	objectPath := pathParts[1]
+	value, hasValue := exampleObject["value"]
+	externalValue, hasExternalValue := exampleObject["externalValue"].(string)
+	switch {
+	case hasValue && hasExternalValue:
+		return nil, reference, fmt.Errorf("value and externalValue of the example are mutually exclusive")
+	case hasValue:
+		valueLoader := gojsonschema.NewGoLoader(value)
+		return &valueLoader, reference + "/value", nil
+	case hasExternalValue:
+		if strings.Contains(externalValue, "://") {
+			return nil, externalValue, nil
+		}
+		external, _, err := store.Resolve(filePath, externalValue)
+		if err != nil {
+			return nil, externalValue, fmt.Errorf("can't load the external value: %s", err)
+		}
+		externalLoader := gojsonschema.NewGoLoader(external)
+		return &externalLoader, externalValue, nil
+	}
+	return nil, reference, fmt.Errorf("the example has neither value nor externalValue")
+}
 
-	if len(filePath) == 0 || len(objectPath) == 0 {
-		return &simpleLoader, nil
+// Returns the loader of the schema referenced by refPath, served by the file system.
+// Unlike GetReferenceLoader, the schema keeps the URL of its file, so its references are resolved relative to that file.
+func getSchemaLoader(fileSystem http.FileSystem, refPath string) gojsonschema.JSONLoader {
+	pathParts := strings.SplitN(refPath, "#", 2)
+	if len(pathParts) == 2 && !strings.HasPrefix(pathParts[1], "/") {
+		// e.g. schema.json#definitions/user
+		refPath = pathParts[0] + "#/" + pathParts[1]
+	}
+	return gojsonschema.NewReferenceLoaderFileSystem("file://"+refPath, fileSystem)
+}
+
+// Resolves the reference relative to the directory of the spec file.
+// References to files are resolved to absolute paths, gojsonschema requires canonical references.
+// The fragment (JSON Pointer) is kept as is, so keys like `a//b` or `..` aren't cleaned.
+func resolvePath(specPath string, ref string) string {
+	refParts := strings.SplitN(ref, "#", 2)
+	filePath := refParts[0]
+	if len(filePath) == 0 {
+		filePath = filepath.Base(specPath)
+	}
+	path := filepath.Join(filepath.Dir(specPath), filePath)
+	if absolutePath, err := filepath.Abs(path); err == nil {
+		path = absolutePath
 	}
+	// Windows
+	path = strings.ReplaceAll(path, `\`, `/`)
+	if len(refParts) == 2 {
+		path += "#" + refParts[1]
+	}
+	return path
+}
 
-	jsonLoader := gojsonschema.NewReferenceLoader(fmt.Sprintf("file://%s", filePath))
-	jsonPath := TranslateReferenceToJSONPath(objectPath)
-	jsonObj, refErr := jsonLoader.LoadJSON()
-	if refErr != nil {
-		return nil, fmt.Errorf("can't load the path: %s:%s", jsonPath, refErr)
+func isPartialFile(path string) bool {
+	for _, extension := range document.Extensions {
+		if strings.Contains(strings.ToLower(path), ".partial"+extension) {
+			return true
+		}
 	}
+	return false
+}
+
+// `gojsonschema` doesn't handle reference paths that point to specific fields like:
+// file://aaa/bbb.json#definitions/example/something
+// It will return the root object of that JSON file, completely ignoring the part after #
+// As a workaround, this function loads that file and resolves the JSON Pointer to get the referenced object.
+// Related: https://github.com/xeipuuv/gojsonschema/issues/262
+// Files are loaded into a new document.Store, so both the referenced file and its references can be YAML files
+// and nothing is written to the file system.
+func GetReferenceLoader(refPath string) (*gojsonschema.JSONLoader, error) {
+	return getReferenceLoader(document.NewStore(), refPath)
+}
 
-	foundObject, jsonPathErr := jsonpath.Get(jsonPath, jsonObj)
-	if jsonPathErr != nil {
-		return nil, fmt.Errorf("can't find the path: %s:%s %+v ", jsonPath, jsonPathErr, jsonObj)
+// Works like GetReferenceLoader, documents are loaded from the store.
+func getReferenceLoader(store *document.Store, refPath string) (*gojsonschema.JSONLoader, error) {
+	pathParts := strings.SplitN(refPath, "#", 2)
+	if len(pathParts) == 1 || len(pathParts[0]) == 0 || len(pathParts[1]) == 0 {
+		simpleLoader := gojsonschema.NewReferenceLoaderFileSystem(fmt.Sprintf("file://%s", refPath), store)
+		return &simpleLoader, nil
 	}
 
+	foundObject, _, err := store.Resolve(pathParts[0], "#"+pathParts[1])
+	if err != nil {
+		return nil, err
+	}
 	partialLoader := gojsonschema.NewGoLoader(foundObject)
 	return &partialLoader, nil
 }
@@ -253,6 +584,7 @@ func unpackArray(loader gojsonschema.JSONLoader) ([]gojsonschema.JSONLoader, err
 // `jsonpath` throws an error if you try to access fields like this:
 // `$.definitions.request.200`
 // Related: https://github.com/PaesslerAG/jsonpath/issues/23
+// Deprecated: RFC 6901 escapes and keys with dots or brackets aren't supported, use jsonpointer.Get instead.
 func TranslateReferenceToJSONPath(refPath string) string {
 	var jsonPath []string
 	for _, part := range strings.Split(refPath, "/") {
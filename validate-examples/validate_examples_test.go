@@ -3,6 +3,9 @@ package validate_examples
 import (
 	"encoding/json"
 	"fmt"
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/report"
+	"github.com/clearcodehq/openapi-linter/validate"
 	"github.com/stretchr/testify/assert"
 	"github.com/xeipuuv/gojsonschema"
 	"os"
@@ -95,10 +98,10 @@ func TestFindExamples(t *testing.T) {
 			}		
 		}`), &obj)
 		expectedExamples := []Example{
-			{},
+			{schemaPath: "smth.json", example: map[string]interface{}{"foo": "bar", "baz": "buzz"}},
 		}
 		expectedErrors := []error{
-			fmt.Errorf("The reference to schema/example is missing, inline objects aren't supported."),
+			nil,
 		}
 
 		// WHEN
@@ -113,21 +116,17 @@ func TestFindExamples(t *testing.T) {
 		// GIVEN
 		json.Unmarshal([]byte(`{
 			"root": {
-				"example": {
-					"foo": "bar",
-					"baz": "buzz"
-				},
+				"example": { "$ref": "smth.json" },
 				"schema": {
-					"foo": "bar",
-					"baz": "buzz"
+					"type": "object"
 				}
 			}		
 		}`), &obj)
 		expectedExamples := []Example{
-			{},
+			{schema: map[string]interface{}{"type": "object"}, examplePath: "smth.json"},
 		}
 		expectedErrors := []error{
-			fmt.Errorf("The reference to schema/example is missing, inline objects aren't supported."),
+			nil,
 		}
 
 		// WHEN
@@ -138,13 +137,30 @@ func TestFindExamples(t *testing.T) {
 		Assert.Equal(errors, expectedErrors)
 	})
 
+	t.Run("Inline example without schema", func(t *testing.T) {
+		// GIVEN
+		json.Unmarshal([]byte(`{
+			"root": {
+				"type": "object",
+				"example": { "foo": "bar" }
+			}
+		}`), &obj)
+
+		// WHEN
+		examples, errors := findExamplesHelper(obj)
+
+		// THEN
+		Assert.Len(examples, 0, "Only examples next to a schema are validated")
+		Assert.Len(errors, 0)
+	})
+
 	// Create parametrized test cases for the invalid json types.
-	exampleInvalidObjectTestCases := []string{
-		"[]",
-		"null",
-		"1",
+	exampleInvalidObjectTestCases := map[string]interface{}{
+		"[]":   []interface{}{},
+		"null": nil,
+		"1":    float64(1),
 	}
-	for _, invalidObjectTestCase := range exampleInvalidObjectTestCases {
+	for invalidObjectTestCase, inlineValue := range exampleInvalidObjectTestCases {
 		t.Run(fmt.Sprintf("Example is not an object but: %s", invalidObjectTestCase), func(t *testing.T) {
 			// GIVEN
 			json.Unmarshal([]byte(fmt.Sprintf(`{
@@ -154,10 +170,10 @@ func TestFindExamples(t *testing.T) {
 				}		
 			}`, invalidObjectTestCase)), &obj)
 			expectedExamples := []Example{
-				{},
+				{schemaPath: "smth.json", example: inlineValue},
 			}
 			expectedErrors := []error{
-				fmt.Errorf("Can't cast the schema/example object to map[string]."),
+				nil,
 			}
 			// WHEN
 			examples, errors := findExamplesHelper(obj)
@@ -179,7 +195,7 @@ func TestFindExamples(t *testing.T) {
 				{},
 			}
 			expectedErrors := []error{
-				fmt.Errorf("Can't cast the schema/example object to map[string]."),
+				fmt.Errorf("Can't cast the schema object to map[string]."),
 			}
 
 			// WHEN
@@ -201,8 +217,8 @@ func TestFindExamples(t *testing.T) {
 		}`), &obj)
 		expectedExamples := []Example{
 			{
-				"aa.json",
-				"bb.json",
+				schemaPath:  "aa.json",
+				examplePath: "bb.json",
 			},
 		}
 		expectedErrors := []error{
@@ -216,6 +232,59 @@ func TestFindExamples(t *testing.T) {
 		Assert.Equal(examples, expectedExamples)
 		Assert.Equal(errors, expectedErrors)
 	})
+	t.Run("Map of examples", func(t *testing.T) {
+		// GIVEN
+		examplesObj := map[string]interface{}{}
+		json.Unmarshal([]byte(`{
+			"root": {
+				"examples": {
+					"b": { "$ref": "#/components/examples/B" },
+					"a": { "value": 1 },
+					"c": 3
+				},
+				"schema": { "$ref": "aa.json" }
+			}
+		}`), &examplesObj)
+		expectedExamples := []Example{
+			{schemaPath: "aa.json", name: "a", exampleObject: map[string]interface{}{"value": 1.0}},
+			{schemaPath: "aa.json", name: "b", exampleObject: map[string]interface{}{"$ref": "#/components/examples/B"}},
+			{},
+		}
+		expectedErrors := []error{
+			nil,
+			nil,
+			fmt.Errorf("example \"c\": Can't cast the example object to map[string]."),
+		}
+
+		// WHEN
+		examples, errors := findExamplesHelper(examplesObj)
+
+		// THEN
+		Assert.Equal(expectedExamples, examples)
+		Assert.Equal(expectedErrors, errors)
+	})
+
+	t.Run("Swagger 2.0 examples are values by the MIME type", func(t *testing.T) {
+		// GIVEN
+		examplesObj := map[string]interface{}{}
+		json.Unmarshal([]byte(`{
+			"swagger": "2.0",
+			"root": {
+				"examples": { "application/json": { "value": 1 } },
+				"schema": { "$ref": "aa.json" }
+			}
+		}`), &examplesObj)
+		expectedExamples := []Example{
+			{schemaPath: "aa.json", name: "application/json", example: map[string]interface{}{"value": 1.0}},
+		}
+
+		// WHEN
+		examples, errors := findExamplesHelper(examplesObj)
+
+		// THEN
+		Assert.Equal(expectedExamples, examples)
+		Assert.Equal([]error{nil}, errors)
+	})
 }
 
 // ScanForExamples is a function that's heavily IO based and it doesn't implement the dependency injection pattern.
@@ -259,6 +328,185 @@ func TestScanForExamples(t *testing.T) {
 		// THEN
 		Assert.Len(errors, 0)
 	})
+	t.Run("YAML documents and references", func(t *testing.T) {
+		// GIVEN
+		specPath := filepath.Join(getFixturesPath("yaml_spec"), "spec.yaml")
+
+		// WHEN
+		errors, _ := ScanForExampleErrors(getFixturesPath("yaml_spec"))
+
+		// THEN
+		Assert.Len(errors, 1)
+		Assert.Equal(specPath, errors[0].FilePath)
+		Assert.Equal("/paths/~1users/get/responses/200/content/application~1json", errors[0].JsonPointer)
+		Assert.Equal(7, errors[0].Line)
+		Assert.Equal(13, errors[0].Column)
+		Assert.EqualError(errors[0].Err, "example.yaml#/users/0: address.city: Invalid type. Expected: string, given: integer")
+	})
+	t.Run("Inline examples and schemas", func(t *testing.T) {
+		// GIVEN
+		specPath := filepath.Join(getFixturesPath("inline_examples"), "spec.yaml")
+
+		// WHEN
+		errors, checks := ScanForExampleErrors(getFixturesPath("inline_examples"))
+
+		// THEN
+		Assert.Len(checks, 3)
+		Assert.Len(errors, 2)
+		Assert.Equal(specPath+":9:11", errors[0].Finding().Location())
+		Assert.EqualError(errors[0].Err, "#/paths/~1users/get/parameters/0/example: (root): Must be greater than or equal to 1")
+		Assert.Equal(specPath+":19:13", errors[1].Finding().Location())
+		Assert.EqualError(errors[1].Err, "#/paths/~1users/get/responses/200/content/application~1json/example: id: Invalid type. Expected: integer, given: string")
+	})
+	t.Run("Maps of examples", func(t *testing.T) {
+		// GIVEN
+		specPath := filepath.Join(getFixturesPath("examples_map"), "spec.yaml")
+
+		// WHEN
+		errors, checks := ScanForExampleErrors(getFixturesPath("examples_map"))
+
+		// THEN
+		Assert.Len(checks, 7, "Every entry of the examples maps and components/examples is checked")
+		Assert.Len(errors, 3)
+		Assert.Equal(specPath+":17:13", errors[0].Finding().Location())
+		Assert.EqualError(errors[0].Err, `example "zero": #/paths/~1users/get/parameters/0/examples/zero/value: (root): Must be greater than or equal to 1`)
+		Assert.Equal(specPath+":29:17", errors[1].Finding().Location())
+		Assert.EqualError(errors[1].Err, `example "referenced": #/components/examples/InvalidUser/value: id: Invalid type. Expected: integer, given: string`)
+		Assert.Equal(specPath+":47:5", errors[2].Finding().Location())
+		Assert.Contains(errors[2].Err.Error(), `example "Missing": examples/missing.json: can't load the external value`)
+	})
+	t.Run("Values embedded in schemas", func(t *testing.T) {
+		// GIVEN
+		specPath := filepath.Join(getFixturesPath("schema_values"), "spec.yaml")
+		userPath := filepath.Join(getFixturesPath("schema_values"), "user.json")
+
+		// WHEN
+		errors, checks := ScanForExampleErrors(getFixturesPath("schema_values"))
+
+		// THEN
+		Assert.Len(checks, 2)
+		Assert.Len(errors, 2)
+		Assert.Equal(report.Finding{
+			File:     specPath,
+			Pointer:  "/paths/~1users/get/parameters/0/schema/default",
+			Line:     14,
+			Column:   22,
+			Rule:     validate.SchemaValuesRule,
+			Message:  "(root): Must be less than or equal to 100",
+			Severity: report.SeverityError,
+		}, errors[0].Finding())
+		Assert.Equal(userPath+":6:18", errors[1].Finding().Location())
+		Assert.Equal("/properties/name/example", errors[1].Finding().Pointer)
+	})
+	t.Run("Nullable schemas of OpenAPI 3.0 allow null", func(t *testing.T) {
+		// GIVEN
+		specPath := filepath.Join(getFixturesPath("nullable"), "spec.yaml")
+
+		// WHEN
+		errors, checks := ScanForExampleErrors(getFixturesPath("nullable"))
+
+		// THEN
+		Assert.Len(checks, 3)
+		Assert.Len(errors, 1)
+		Assert.Equal(specPath+":9:11", errors[0].Finding().Location())
+		Assert.Equal("/paths/~1users~1{id}/get/parameters/0", errors[0].JsonPointer)
+		Assert.Contains(errors[0].Error(), "Invalid type. Expected: integer, given: null")
+	})
+	t.Run("Roots of files are schemas if they're referenced as schemas, not as examples", func(t *testing.T) {
+		// GIVEN
+		schemaPath := filepath.Join(getFixturesPath("file_roots"), "field_schema.json")
+
+		// WHEN
+		errors, _ := ScanForExampleErrors(getFixturesPath("file_roots"))
+
+		// THEN
+		Assert.Len(errors, 1, "The example payload isn't validated as a schema")
+		Assert.Equal(schemaPath, errors[0].FilePath)
+		Assert.Equal("/properties/maxLength/default", errors[0].Finding().Pointer)
+		Assert.Equal(validate.SchemaValuesRule, errors[0].Finding().Rule)
+	})
+	t.Run("Recursive schemas and reference cycles", func(t *testing.T) {
+		// WHEN
+		errors, checks := ScanForExampleErrors(getFixturesPath("reference_cycles"))
+
+		// THEN
+		Assert.Len(checks, 4)
+		messages := []string{}
+		for _, err := range errors {
+			messages = append(messages, fmt.Sprintf("%d:%d %s", err.Line, err.Column, err.Err))
+		}
+		Assert.Equal([]string{
+			"12:13 #/paths/~1comments/get/responses/200/content/application~1json/example: replies.0.replies.0.text: Invalid type. Expected: string, given: integer",
+			`43:5 example "Back": #/components/examples/Back: the example references itself: #/components/examples/Back -> #/components/examples/Loop -> #/components/examples/Back`,
+		}, messages, "The cycle of examples is reported once, at its first node")
+	})
+	t.Run("Schemas with reference cycles that can't be satisfied aren't validated", func(t *testing.T) {
+		// GIVEN
+		specPath := filepath.Join(getFixturesPath("reference_cycles"), "spec.yaml")
+		example := Example{schemaPath: "#/components/schemas/Alias", example: "text"}
+
+		// WHEN
+		errors := validateExample(document.NewStore(), specPath, "/paths/~1comments/get/responses/200/content/text~1plain", example)
+
+		// THEN
+		Assert.Len(errors, 1)
+		Assert.Contains(errors[0].Error(), "the schema has a reference cycle that can't be satisfied: #/components/schemas/Alias -> #/components/schemas/Alias")
+	})
+	t.Run("Extensions are matched in any case", func(t *testing.T) {
+		// GIVEN
+		specPath := filepath.Join(getFixturesPath("upper_case_extensions"), "Spec.YAML")
+
+		// WHEN
+		errors, checks := ScanForExampleErrors(getFixturesPath("upper_case_extensions"))
+
+		// THEN
+		Assert.Len(checks, 1, "Partial files are skipped")
+		Assert.Len(errors, 1)
+		Assert.Equal(specPath, errors[0].FilePath)
+		Assert.Equal("/paths/~1users/get/responses/200/content/application~1json", errors[0].JsonPointer)
+	})
+	t.Run("Unparsable files don't stop the scan", func(t *testing.T) {
+		// GIVEN
+		brokenPath := filepath.Join(getFixturesPath("unparsable_file"), "broken.json")
+
+		// WHEN
+		errors, checks := ScanForExampleErrors(getFixturesPath("unparsable_file"))
+
+		// THEN
+		Assert.Len(checks, 1)
+		Assert.Len(errors, 1)
+		finding := errors[0].Finding()
+		Assert.Equal(document.ParseErrorRule, finding.Rule)
+		Assert.Equal(brokenPath+":6:1", finding.Location())
+		Assert.Contains(finding.Message, "can't unmarshal contents")
+	})
+	t.Run("Errors point to the node with the example", func(t *testing.T) {
+		// GIVEN
+		specPath := filepath.Join(getFixturesPath("invalid_example"), "spec.json")
+
+		// WHEN
+		errors, checks := ScanForExampleErrors(getFixturesPath("invalid_example"))
+
+		// THEN
+		Assert.Equal([]report.Check{{File: specPath, Pointer: "/rootObject", Rule: Rule}}, checks)
+		Assert.Len(errors, 2)
+		for _, err := range errors {
+			finding := err.Finding()
+			Assert.Equal(specPath, finding.File)
+			Assert.Equal("/rootObject", finding.Pointer)
+			Assert.Equal(2, finding.Line)
+			Assert.Equal(17, finding.Column)
+			Assert.Equal(Rule, finding.Rule)
+			Assert.Equal(err.Error(), finding.Message)
+		}
+	})
+	t.Run("Missing directories fail the scan", func(t *testing.T) {
+		// WHEN
+		_, _, err := ScanDirForExampleErrors(filepath.Join(testsRoot, "..", "tests", "validate_examples", "scan_for_examples", "missing"))
+
+		// THEN
+		Assert.True(os.IsNotExist(err))
+	})
 }
 
 func TestGetReferenceLoader(t *testing.T) {
@@ -278,10 +526,10 @@ func TestGetReferenceLoader(t *testing.T) {
 	getReferenceLoaderHelper := func(fixtureName string) (map[string]interface{}, gojsonschema.JSONLoader) {
 		fixturePath := getFixturesPath(fixtureName)
 		loader, _ := GetReferenceLoader(fixturePath)
-		obj, _ := loader.LoadJSON()
+		obj, _ := (*loader).LoadJSON()
 		objMap, _ := obj.(map[string]interface{})
 
-		return objMap, loader
+		return objMap, *loader
 	}
 
 	t.Run("Load file without the path reference", func(t *testing.T) {
@@ -302,12 +550,34 @@ func TestGetReferenceLoader(t *testing.T) {
 			"eee": "fff",
 		}
 		// WHEN
-		extractedObject, partialPath := getReferenceLoaderHelper("nested.json#/aaa/ccc")
+		extractedObject, _ := getReferenceLoaderHelper("nested.json#/aaa/ccc")
 
 		// THEN
 		Assert.Equal(extractedObject, expectedObject)
+	})
 
-		RemovePartialLoader(partialPath)
+	t.Run("Load File with an escaped JSON Pointer", func(t *testing.T) {
+		testCases := map[string]string{
+			"escaped.json#/paths/~1users~1{id}/responses/200/application~1vnd.api+json": "1",
+			"escaped.json#/paths/~1users~1%7Bid%7D/responses/200/a~0b%20c":              "2",
+			"escaped.json#/paths/~1users~1{id}/responses/200/[0]":                       "3",
+		}
+		for reference, expectedID := range testCases {
+			// WHEN
+			extractedObject, _ := getReferenceLoaderHelper(reference)
+
+			// THEN
+			Assert.Equal(map[string]interface{}{"id": expectedID}, extractedObject, reference)
+		}
+	})
+
+	t.Run("Missing segments are named in the error", func(t *testing.T) {
+		// WHEN
+		loader, err := GetReferenceLoader(getFixturesPath("escaped.json#/paths/~1users~1{id}/responses/404"))
+
+		// THEN
+		Assert.Nil(loader)
+		Assert.Contains(err.Error(), `can't resolve the JSON pointer "/paths/~1users~1{id}/responses/404" at "/paths/~1users~1{id}/responses": "404" not found`)
 	})
 }
 
@@ -0,0 +1,63 @@
+package validate_refs
+
+import (
+	"sort"
+
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/references"
+	"github.com/clearcodehq/openapi-linter/report"
+	"github.com/clearcodehq/openapi-linter/validate"
+)
+
+// Checks every `$ref` of all documents within the directory, both JSON and YAML documents.
+// Files that can't be read or parsed are reported as validation errors and don't stop the scan.
+// Returns validation errors sorted by the file, position and rule, and the list of all checked documents.
+func ValidateAllRefsInDir(dir string) ([]validate.ValidationError, []report.Check, error) {
+	return ValidateAllRefsInStore(document.NewStore(), dir)
+}
+
+// Works like ValidateAllRefsInDir, documents are loaded from the store.
+func ValidateAllRefsInStore(store *document.Store, dir string) ([]validate.ValidationError, []report.Check, error) {
+	files, err := validate.FindJsonFiles(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validationErrors := []validate.ValidationError{}
+	checks := []report.Check{}
+	// Every document is read and parsed once, also if it's referenced by many others
+	for _, file := range files {
+		if fileErr := ValidateRefsInFile(store, file, &validationErrors, &checks); fileErr != nil {
+			validationErrors = append(validationErrors, validate.FileError(file, fileErr))
+		}
+	}
+	sort.SliceStable(validationErrors, func(i, j int) bool {
+		return report.Less(validationErrors[i].Finding(), validationErrors[j].Finding())
+	})
+	return validationErrors, checks, nil
+}
+
+// Opens a file from the store and reports every `$ref` that can't be resolved, at the position of the `$ref` value.
+// Checked documents are recorded in checks (if not nil).
+func ValidateRefsInFile(store *document.Store, path string, validationErrors *[]validate.ValidationError, checks *[]report.Check) error {
+	refsDocument, err := store.Load(path)
+	if err != nil {
+		return err
+	}
+
+	if checks != nil {
+		*checks = append(*checks, report.Check{File: path, Rule: references.UnresolvedRule})
+	}
+	for _, unresolved := range references.FindUnresolved(store, document.URI(path)) {
+		position := refsDocument.Position(unresolved.Pointer)
+		*validationErrors = append(*validationErrors, validate.ValidationError{
+			FilePath:    path,
+			JsonPointer: unresolved.Pointer,
+			Line:        position.Line,
+			Column:      position.Column,
+			Rule:        references.UnresolvedRule,
+			Err:         unresolved,
+		})
+	}
+	return nil
+}
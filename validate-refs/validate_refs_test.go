@@ -0,0 +1,49 @@
+package validate_refs
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/clearcodehq/openapi-linter/references"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAllRefsInDir(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	_, testsFile, _, _ := runtime.Caller(0)
+	dir := filepath.Join(filepath.Dir(testsFile), "..", "tests", "validate_refs", "validate_all_refs_in_dir")
+	spec, broken, user := filepath.Join(dir, "openapi.yaml"), filepath.Join(dir, "schemas", "broken.json"), filepath.Join(dir, "schemas", "user.yaml")
+	expectedFindings := []string{
+		spec + ":9:17 /paths/~1users/get/parameters/0/$ref",
+		spec + ":16:23 /paths/~1users/get/responses/200/content/application~1json/schema/$ref",
+		spec + ":19:23 /paths/~1users/get/responses/200/content/application~1xml/schema/$ref",
+		broken + ":1:10 /$ref",
+		user + ":6:15 /definitions/User/properties/address/$ref",
+	}
+	expectedMessages := []string{
+		`can't resolve the reference "#/components/parameters/Limt": can't resolve the JSON pointer "/components/parameters/Limt" at "/components/parameters": "Limt" not found (nearest existing path: #/components/parameters/Limit)`,
+		`can't resolve the reference "schemas/user.yaml#/definitions/Usr": can't resolve the JSON pointer "/definitions/Usr" at "/definitions": "Usr" not found (nearest existing path: schemas/user.yaml#/definitions/User)`,
+		`can't resolve the reference "schemas/users.yaml": the file doesn't exist (nearest existing path: schemas/user.yaml)`,
+		`can't resolve the reference "user.yaml#/definitions/User/properties/address/type": can't resolve the JSON pointer "/definitions/User/properties/address/type" at "/definitions/User/properties/address": "type" not found (nearest existing path: user.yaml#/definitions/User/properties/address)`,
+		`can't resolve the reference "../missing/address.json": the file doesn't exist (nearest existing path: ../)`,
+	}
+
+	// WHEN
+	validationErrors, checks, err := ValidateAllRefsInDir(dir)
+
+	// THEN
+	Assert.Nil(err)
+	findings, messages := []string{}, []string{}
+	for _, validationError := range validationErrors {
+		finding := validationError.Finding()
+		Assert.Equal(references.UnresolvedRule, finding.Rule)
+		findings = append(findings, finding.Location()+" "+finding.Pointer)
+		messages = append(messages, finding.Message)
+	}
+	Assert.Equal(expectedFindings, findings)
+	Assert.Equal(expectedMessages, messages)
+	Assert.Len(checks, 3, "Every document is checked, remote references aren't")
+}
@@ -0,0 +1,145 @@
+package validate_spec
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/report"
+	"github.com/clearcodehq/openapi-linter/validate"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// Name of the check reported with every structural error
+const Rule = "valid-spec"
+
+// Describes the check in reports that list rules
+const RuleDescription = "OpenAPI documents must be valid against the official OpenAPI 2.0, 3.0 or 3.1 schema."
+
+// Meta-schemas of the OpenAPI documents, by the OpenAPI version
+var specSchemas = map[string]string{
+	validate.OpenAPI20: openAPI20Schema,
+	validate.OpenAPI30: openAPI30Schema,
+	validate.OpenAPI31: openAPI31Schema,
+}
+
+// Compiled meta-schemas, by the OpenAPI version
+var compiledSchemas = map[string]*gojsonschema.Schema{}
+
+// Validates all OpenAPI documents within the directory, files without the `swagger` or `openapi` field are skipped.
+// Files that can't be read or parsed are reported as validation errors and don't stop the scan.
+// Returns validation errors sorted by the file, position and rule, and the list of all validated documents.
+func ValidateAllSpecsInDir(dir string) ([]validate.ValidationError, []report.Check, error) {
+	return ValidateAllSpecsInStore(document.NewStore(), dir)
+}
+
+// Works like ValidateAllSpecsInDir, documents are loaded from the store.
+func ValidateAllSpecsInStore(store *document.Store, dir string) ([]validate.ValidationError, []report.Check, error) {
+	files, err := validate.FindJsonFiles(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validationErrors := []validate.ValidationError{}
+	checks := []report.Check{}
+	for _, file := range files {
+		// documents keep the path as found in the directory, so validation errors do too
+		specDocument, fileErr := document.Load(file)
+		if fileErr != nil {
+			validationErrors = append(validationErrors, validate.FileError(file, fileErr))
+			continue
+		}
+		store.Add(specDocument)
+		ValidateSpecDocument(specDocument, &validationErrors, &checks)
+	}
+	sort.SliceStable(validationErrors, func(i, j int) bool {
+		return report.Less(validationErrors[i].Finding(), validationErrors[j].Finding())
+	})
+	return validationErrors, checks, nil
+}
+
+// Opens a file and validates it against the schema of its OpenAPI version.
+// Files without the `swagger` or `openapi` field aren't OpenAPI documents and aren't validated.
+// Validated documents are recorded in checks (if not nil).
+func ValidateSpecFile(path string, validationErrors *[]validate.ValidationError, checks *[]report.Check) error {
+	specDocument, err := document.Load(path)
+	if err != nil {
+		return err
+	}
+	ValidateSpecDocument(specDocument, validationErrors, checks)
+	return nil
+}
+
+// Validates the parsed document against the schema of its OpenAPI version, documents that aren't OpenAPI documents are skipped.
+// Validated documents are recorded in checks (if not nil).
+func ValidateSpecDocument(specDocument *document.Document, validationErrors *[]validate.ValidationError, checks *[]report.Check) {
+	path := specDocument.Path
+	if !IsSpec(specDocument.Root) {
+		return
+	}
+
+	if checks != nil {
+		*checks = append(*checks, report.Check{File: path, Rule: Rule})
+	}
+	for _, validationError := range ValidateSpec(specDocument.Root) {
+		position := specDocument.Position(validationError.Pointer())
+		validationError.FilePath, validationError.Line, validationError.Column = path, position.Line, position.Column
+		*validationErrors = append(*validationErrors, validationError)
+	}
+}
+
+// Determines if the document declares the OpenAPI version with the `swagger` or `openapi` field.
+func IsSpec(root interface{}) bool {
+	object, isObject := root.(map[string]interface{})
+	if !isObject {
+		return false
+	}
+	_, isSwagger := object["swagger"]
+	_, isOpenAPI := object["openapi"]
+	return isSwagger || isOpenAPI
+}
+
+// Validates the document against the schema of the OpenAPI version declared by the `swagger` or `openapi` field.
+// Returns all violations of the schema, with Field pointing to the invalid value.
+func ValidateSpec(root interface{}) []validate.ValidationError {
+	version := validate.OpenAPIVersion(root)
+	if len(version) == 0 {
+		field := "/openapi"
+		if object, _ := root.(map[string]interface{}); object["swagger"] != nil {
+			field = "/swagger"
+		}
+		return []validate.ValidationError{{
+			Field: field,
+			Rule:  Rule,
+			Err:   fmt.Errorf("unsupported OpenAPI version, use one of: %s, %s, %s", validate.OpenAPI20, validate.OpenAPI30, validate.OpenAPI31),
+		}}
+	}
+
+	specSchema, err := getSpecSchema(version)
+	if err != nil {
+		return []validate.ValidationError{{Rule: Rule, Err: err}}
+	}
+	result, err := specSchema.Validate(gojsonschema.NewGoLoader(root))
+	if err != nil {
+		return []validate.ValidationError{{Rule: Rule, Err: err}}
+	}
+
+	validationErrors := validate.ResultErrors(result, root)
+	for index := range validationErrors {
+		validationErrors[index].Rule = Rule
+	}
+	return validationErrors
+}
+
+// Compiles the schema of the OpenAPI version.
+func getSpecSchema(version string) (*gojsonschema.Schema, error) {
+	if specSchema, compiled := compiledSchemas[version]; compiled {
+		return specSchema, nil
+	}
+	specSchema, err := gojsonschema.NewSchema(gojsonschema.NewStringLoader(specSchemas[version]))
+	if err != nil {
+		return nil, err
+	}
+	compiledSchemas[version] = specSchema
+	return specSchema, nil
+}
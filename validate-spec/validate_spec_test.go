@@ -0,0 +1,79 @@
+package validate_spec
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateAllSpecsInDir(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	_, testsFile, _, _ := runtime.Caller(0)
+	dir := filepath.Join(filepath.Dir(testsFile), "..", "tests", "validate_spec", "validate_all_specs_in_dir")
+	invalid, unsupported := filepath.Join(dir, "invalid.yaml"), filepath.Join(dir, "unsupported.yaml")
+	expectedFindings := []string{
+		invalid + ":2:1 /info",
+		invalid + ":8:11 /paths/~1users/get/parameters/0",
+		invalid + ":8:11 /paths/~1users/get/parameters/0",
+		invalid + ":8:11 /paths/~1users/get/parameters/0",
+		invalid + ":9:15 /paths/~1users/get/parameters/0/in",
+		invalid + ":13:9 /paths/~1users/get/responses/200",
+		invalid + ":13:9 /paths/~1users/get/responses/200",
+		unsupported + ":1:10 /openapi",
+	}
+
+	// WHEN
+	validationErrors, checks, err := ValidateAllSpecsInDir(dir)
+
+	// THEN
+	Assert.Nil(err)
+	findings := []string{}
+	for _, validationError := range validationErrors {
+		finding := validationError.Finding()
+		Assert.Equal(Rule, finding.Rule)
+		findings = append(findings, finding.Location()+" "+finding.Pointer)
+	}
+	Assert.Equal(expectedFindings, findings)
+	Assert.Len(checks, 5, "Files without the swagger or openapi field aren't checked")
+}
+
+func TestValidateSpec(t *testing.T) {
+	Assert := assert.New(t)
+
+	t.Run("Valid documents of every version", func(t *testing.T) {
+		for _, root := range []map[string]interface{}{
+			{"swagger": "2.0", "info": map[string]interface{}{"title": "a", "version": "1"}, "paths": map[string]interface{}{}},
+			{"openapi": "3.0.0", "info": map[string]interface{}{"title": "a", "version": "1"}, "paths": map[string]interface{}{}},
+			{"openapi": "3.1.0", "info": map[string]interface{}{"title": "a", "version": "1"}, "webhooks": map[string]interface{}{}},
+		} {
+			Assert.Empty(ValidateSpec(root), root)
+		}
+	})
+	t.Run("The version picks the schema", func(t *testing.T) {
+		// GIVEN
+		root := map[string]interface{}{"swagger": "2.0", "info": map[string]interface{}{"title": "a", "version": "1"}, "webhooks": map[string]interface{}{}}
+
+		// WHEN
+		validationErrors := ValidateSpec(root)
+
+		// THEN
+		pointers := []string{}
+		for _, validationError := range validationErrors {
+			pointers = append(pointers, validationError.Pointer())
+		}
+		Assert.Equal([]string{"", ""}, pointers, "Swagger 2.0 requires paths and doesn't allow webhooks")
+	})
+	t.Run("Unsupported versions", func(t *testing.T) {
+		// WHEN
+		validationErrors := ValidateSpec(map[string]interface{}{"swagger": "1.2"})
+
+		// THEN
+		Assert.Len(validationErrors, 1)
+		Assert.Equal("/swagger", validationErrors[0].Field)
+		Assert.Equal("unsupported OpenAPI version, use one of: 2.0, 3.0, 3.1", validationErrors[0].Err.Error())
+	})
+}
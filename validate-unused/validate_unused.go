@@ -0,0 +1,130 @@
+package validate_unused
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/bmatcuk/doublestar"
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/references"
+	"github.com/clearcodehq/openapi-linter/report"
+	"github.com/clearcodehq/openapi-linter/validate"
+)
+
+// Finds the components and documents within the directory that no operation reaches, both JSON and YAML documents.
+// The allowlist holds patterns of intentionally public components and documents, they're used as if an operation reached them:
+// `<file glob>` or `<file glob>#<JSON Pointer glob>`, files relative to the directory, e.g. `shared/*.yaml` or `**#/components/schemas/Public*`.
+// Files that can't be read or parsed are reported as validation errors and don't stop the scan.
+// Returns validation errors sorted by the file, position and rule, and the list of all checked documents.
+func FindUnusedInDir(dir string, allowlist []string) ([]validate.ValidationError, []report.Check, error) {
+	return FindUnusedInStore(document.NewStore(), dir, allowlist)
+}
+
+// Works like FindUnusedInDir, documents are loaded from the store.
+func FindUnusedInStore(store *document.Store, dir string, allowlist []string) ([]validate.ValidationError, []report.Check, error) {
+	for _, pattern := range allowlist {
+		if err := ValidatePattern(pattern); err != nil {
+			return nil, nil, err
+		}
+	}
+	files, err := validate.FindJsonFiles(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validationErrors := []validate.ValidationError{}
+	checks := []report.Check{}
+	loaded := []string{}
+	for _, file := range files {
+		if _, fileErr := store.Load(file); fileErr != nil {
+			validationErrors = append(validationErrors, validate.FileError(file, fileErr))
+			continue
+		}
+		loaded = append(loaded, file)
+		checks = append(checks,
+			report.Check{File: file, Rule: references.UnusedComponentRule},
+			report.Check{File: file, Rule: references.OrphanedFileRule})
+	}
+
+	validationErrors = append(validationErrors, FindUnused(store, dir, loaded, allowlist)...)
+	sort.SliceStable(validationErrors, func(i, j int) bool {
+		return report.Less(validationErrors[i].Finding(), validationErrors[j].Finding())
+	})
+	return validationErrors, checks, nil
+}
+
+// Finds the components and documents that no operation reaches among the files of the directory, loaded from the store.
+// Allowlist patterns must be valid. Returns validation errors in the order of files and components.
+func FindUnused(store *document.Store, dir string, files []string, allowlist []string) []validate.ValidationError {
+	validationErrors := []validate.ValidationError{}
+	uris := []string{}
+	paths := map[string]string{}
+	for _, file := range files {
+		uri := document.URI(file)
+		uris = append(uris, uri)
+		paths[uri] = file
+	}
+
+	isPublic := func(node references.Node) bool {
+		relativePath, err := filepath.Rel(dir, paths[node.URI])
+		if err != nil {
+			return false
+		}
+		for _, pattern := range allowlist {
+			if matched, _ := matchAllowed(pattern, filepath.ToSlash(relativePath), node.Pointer); matched {
+				return true
+			}
+		}
+		return false
+	}
+	for _, node := range references.FindUnused(store, uris, isPublic) {
+		path := paths[node.URI]
+		if len(node.Pointer) == 0 {
+			validationErrors = append(validationErrors, validate.ValidationError{
+				FilePath: path,
+				Rule:     references.OrphanedFileRule,
+				Err:      fmt.Errorf("the document isn't an OpenAPI document and no operation references it"),
+			})
+			continue
+		}
+		unusedDocument, _ := store.Load(path)
+		position := unusedDocument.Position(node.Pointer)
+		validationErrors = append(validationErrors, validate.ValidationError{
+			FilePath:    path,
+			JsonPointer: node.Pointer,
+			Line:        position.Line,
+			Column:      position.Column,
+			Rule:        references.UnusedComponentRule,
+			Err:         fmt.Errorf("the component %q isn't referenced by any operation", "#"+node.Pointer),
+		})
+	}
+	return validationErrors
+}
+
+// Returns an error if a glob of the allowlist pattern is malformed.
+func ValidatePattern(pattern string) error {
+	for _, glob := range strings.SplitN(pattern, "#", 2) {
+		// the glob is matched against itself, so every bracket expression is parsed
+		if _, err := doublestar.Match(glob, glob); err != nil {
+			return fmt.Errorf("invalid allowlist pattern %q: %s", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Matches the allowlist pattern against the file path (relative, with slashes) and the JSON Pointer of a component.
+// Patterns without a pointer match the whole document, patterns without a file match every document.
+func matchAllowed(pattern string, relativePath string, pointer string) (bool, error) {
+	parts := strings.SplitN(pattern, "#", 2)
+	filePattern := parts[0]
+	if len(filePattern) == 0 {
+		filePattern = "**"
+	}
+	matched, err := doublestar.Match(filePattern, relativePath)
+	if err != nil || !matched || len(parts) == 1 {
+		return matched, err
+	}
+	return doublestar.Match(parts[1], pointer)
+}
@@ -0,0 +1,79 @@
+package validate_unused
+
+import (
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testsDir() string {
+	_, testsFile, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(testsFile), "..", "tests", "validate_unused", "find_unused_in_dir")
+}
+
+func TestFindUnusedInDir(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	dir := testsDir()
+	spec, common, old := filepath.Join(dir, "openapi.yaml"), filepath.Join(dir, "schemas", "common.yaml"), filepath.Join(dir, "schemas", "old.json")
+	allowlist := []string{"#/components/schemas/Public*", "shared/*.yaml"}
+	expectedFindings := []string{
+		spec + ":20:5 /components/parameters/Offset unused-component",
+		spec + ":34:5 /components/responses/NotFound unused-component",
+		spec + ":52:5 /components/schemas/Legacy unused-component",
+		spec + ":57:5 /components/schemas/Tag unused-component",
+		common + ":6:3 /definitions/Unused unused-component",
+		old + "  orphaned-file",
+	}
+
+	// WHEN
+	validationErrors, checks, err := FindUnusedInDir(dir, allowlist)
+
+	// THEN
+	Assert.Nil(err)
+	findings := []string{}
+	for _, validationError := range validationErrors {
+		finding := validationError.Finding()
+		findings = append(findings, finding.Location()+" "+finding.Pointer+" "+finding.Rule)
+	}
+	Assert.Equal(expectedFindings, findings)
+	Assert.Equal(`the component "#/components/parameters/Offset" isn't referenced by any operation`, validationErrors[0].Err.Error())
+	Assert.Len(checks, 8, "Both rules are checked in every document")
+}
+
+func TestFindUnusedInDirWithoutAllowlist(t *testing.T) {
+	Assert := assert.New(t)
+
+	// GIVEN
+	dir := testsDir()
+	spec, common, errors := filepath.Join(dir, "openapi.yaml"), filepath.Join(dir, "schemas", "common.yaml"), filepath.Join(dir, "shared", "errors.yaml")
+
+	// WHEN
+	validationErrors, _, err := FindUnusedInDir(dir, nil)
+
+	// THEN
+	Assert.Nil(err)
+	findings := []string{}
+	for _, validationError := range validationErrors {
+		finding := validationError.Finding()
+		findings = append(findings, finding.File+" "+finding.Pointer)
+	}
+	Assert.Contains(findings, spec+" /components/schemas/PublicError")
+	Assert.Contains(findings, common+" /definitions/Code", "Schemas referenced only by unused components are unused too")
+	Assert.Contains(findings, errors+" ", "Components of orphaned files aren't reported separately")
+	Assert.NotContains(findings, errors+" /components/schemas/Error")
+	Assert.NotContains(findings, spec+" /components/schemas/Dog", "Variants of reached schemas with a discriminator are used")
+}
+
+func TestFindUnusedInDirWithInvalidAllowlist(t *testing.T) {
+	Assert := assert.New(t)
+
+	// WHEN
+	_, _, err := FindUnusedInDir(testsDir(), []string{"shared/[*.yaml"})
+
+	// THEN
+	Assert.NotNil(err)
+}
@@ -0,0 +1,61 @@
+package validate
+
+import (
+	"net/http"
+
+	"github.com/clearcodehq/openapi-linter/document"
+)
+
+// Returns the file system serving the schemas of the file to gojsonschema.
+// Schemas of OpenAPI 3.0 documents are served with the `nullable` keyword translated by TranslateNullable,
+// other documents are served as they are.
+func SchemaFileSystem(store *document.Store, filePath string) http.FileSystem {
+	schemaDocument, err := store.Load(filePath)
+	if err != nil || OpenAPIVersion(schemaDocument.Root) != OpenAPI30 {
+		return store
+	}
+	return store.Converted(TranslateNullable)
+}
+
+// Returns a copy of the value with the OpenAPI 3.0 `nullable: true` of every object within it translated to JSON Schema:
+// "null" is added to the `type` of the object. Objects without a `type` allow null anyway, `enum` has to list null
+// explicitly, like OpenAPI 3.0.3 specifies. The value isn't modified.
+func TranslateNullable(value interface{}) interface{} {
+	switch node := value.(type) {
+	case map[string]interface{}:
+		translated := make(map[string]interface{}, len(node))
+		for key, item := range node {
+			translated[key] = TranslateNullable(item)
+		}
+		if nullable, _ := node["nullable"].(bool); nullable && node["type"] != nil {
+			translated["type"] = withNullType(node["type"])
+		}
+		return translated
+	case []interface{}:
+		translated := make([]interface{}, len(node))
+		for index, item := range node {
+			translated[index] = TranslateNullable(item)
+		}
+		return translated
+	}
+	return value
+}
+
+// Adds "null" to the type (or the list of types).
+func withNullType(schemaType interface{}) interface{} {
+	switch types := schemaType.(type) {
+	case string:
+		if types == "null" {
+			return types
+		}
+		return []interface{}{types, "null"}
+	case []interface{}:
+		for _, item := range types {
+			if item == "null" {
+				return types
+			}
+		}
+		return append(append([]interface{}{}, types...), "null")
+	}
+	return schemaType
+}
@@ -0,0 +1,208 @@
+package validate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/jsonpointer"
+	"github.com/clearcodehq/openapi-linter/report"
+)
+
+// Determines where the schemas are looked for
+type Mode string
+
+const (
+	// Every nested object that looks like a schema is validated
+	ModeHeuristic Mode = "heuristic"
+	// Only the schema locations of OpenAPI documents are validated, other files fall back to ModeHeuristic
+	ModeOpenAPI Mode = "openapi"
+)
+
+// Supported modes, the first one is the default
+var Modes = []Mode{ModeHeuristic, ModeOpenAPI}
+
+// Returns an error if the mode isn't supported.
+func ValidateMode(mode Mode) error {
+	for _, supported := range Modes {
+		if mode == supported {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported mode %q, use one of: %s, %s", mode, ModeHeuristic, ModeOpenAPI)
+}
+
+// Supported OpenAPI versions
+const (
+	OpenAPI20 = "2.0"
+	OpenAPI30 = "3.0"
+	OpenAPI31 = "3.1"
+)
+
+// Meta-schemas of the Schema Objects, by the OpenAPI version.
+// OpenAPI 3.1 uses the JSON Schema 2020-12 which isn't supported by gojsonschema, draft-07 is the closest one.
+var openAPIMetaSchemas = map[string]string{
+	OpenAPI20: "http://json-schema.org/draft-04/schema#",
+	OpenAPI30: "http://json-schema.org/draft-04/schema#",
+	OpenAPI31: "http://json-schema.org/draft-07/schema#",
+}
+
+// Returns the OpenAPI version declared by the `swagger` or `openapi` field of the document.
+// Returns an empty string if the document isn't an OpenAPI document or its version isn't supported.
+func OpenAPIVersion(root interface{}) string {
+	object, isObject := root.(map[string]interface{})
+	if !isObject {
+		return ""
+	}
+	if swagger, _ := object["swagger"].(string); swagger == OpenAPI20 {
+		return OpenAPI20
+	}
+	openapi, _ := object["openapi"].(string)
+	for _, version := range []string{OpenAPI30, OpenAPI31} {
+		if openapi == version || strings.HasPrefix(openapi, version+".") {
+			return version
+		}
+	}
+	return ""
+}
+
+// Validates the Schema Objects of the OpenAPI document against the meta-schema of its version.
+// Nested sub-schemas are validated together with the schema object that contains them,
+// values embedded in the schema and its sub-schemas are validated against the (sub-)schema that holds them.
+func validateOpenAPISchemas(store *document.Store, filePath string, version string, root map[string]interface{}, errors *[]ValidationError, checks *[]report.Check) {
+	FindOpenAPISchemas(root, func(pointer string, schema map[string]interface{}) {
+		// Swagger 2.0 allows `file` as the type of the root schema of parameters and responses
+		if schemaType, _ := schema["type"].(string); version == OpenAPI20 && schemaType == "file" {
+			schema = withoutKey(schema, "type")
+		}
+		addSchemaErrors(filePath, pointer, validateSchema(&schema, openAPIMetaSchemas[version]), errors, checks)
+		*errors = append(*errors, ValidateSchemaValues(store, filePath, pointer, schema, checks)...)
+	})
+}
+
+// Returns a shallow copy of the object without the key.
+func withoutKey(object map[string]interface{}, key string) map[string]interface{} {
+	copied := make(map[string]interface{}, len(object))
+	for objectKey, value := range object {
+		if objectKey != key {
+			copied[objectKey] = value
+		}
+	}
+	return copied
+}
+
+// Calls visit with every Schema Object of the OpenAPI 2.0, 3.0 or 3.1 document and the JSON Pointer to it:
+// definitions, components/schemas and the `schema` of parameters, headers, request bodies, responses and media types.
+// Schemas are visited in the order of keys.
+func FindOpenAPISchemas(root map[string]interface{}, visit func(pointer string, schema map[string]interface{})) {
+	walker := openAPIWalker{visit}
+	walker.each("/paths", root["paths"], true, walker.pathItem)
+	walker.each("/webhooks", root["webhooks"], true, walker.pathItem)
+	walker.each("/definitions", root["definitions"], false, walker.schema)
+	walker.each("/parameters", root["parameters"], false, walker.parameter)
+	walker.each("/responses", root["responses"], false, walker.response)
+
+	components, _ := root["components"].(map[string]interface{})
+	walker.each("/components/schemas", components["schemas"], false, walker.schema)
+	walker.each("/components/responses", components["responses"], false, walker.response)
+	walker.each("/components/parameters", components["parameters"], false, walker.parameter)
+	walker.each("/components/requestBodies", components["requestBodies"], false, walker.requestBody)
+	walker.each("/components/headers", components["headers"], false, walker.header)
+	walker.each("/components/callbacks", components["callbacks"], false, walker.callback)
+	walker.each("/components/pathItems", components["pathItems"], false, walker.pathItem)
+}
+
+// HTTP methods of the Path Item Object
+var operationMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Walks the OpenAPI objects that can contain Schema Objects
+type openAPIWalker struct {
+	visit func(pointer string, schema map[string]interface{})
+}
+
+// Calls walk for every value of the object in the order of keys.
+// Specification extensions (`x-` keys) are skipped if skipExtensions is true.
+func (walker openAPIWalker) each(pointer string, value interface{}, skipExtensions bool, walk func(string, map[string]interface{})) {
+	object, _ := value.(map[string]interface{})
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		if !skipExtensions || !strings.HasPrefix(key, "x-") {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if child, isObject := object[key].(map[string]interface{}); isObject {
+			walk(jsonpointer.Append(pointer, key), child)
+		}
+	}
+}
+
+// Calls walk for every object in the array.
+func (walker openAPIWalker) eachItem(pointer string, value interface{}, walk func(string, map[string]interface{})) {
+	items, _ := value.([]interface{})
+	for index, item := range items {
+		if child, isObject := item.(map[string]interface{}); isObject {
+			walk(jsonpointer.Append(pointer, strconv.Itoa(index)), child)
+		}
+	}
+}
+
+func (walker openAPIWalker) schema(pointer string, object map[string]interface{}) {
+	walker.visit(pointer, object)
+}
+
+// Calls walk with the object under the key (if it's an object).
+func (walker openAPIWalker) field(pointer string, object map[string]interface{}, key string, walk func(string, map[string]interface{})) {
+	if child, isObject := object[key].(map[string]interface{}); isObject {
+		walk(jsonpointer.Append(pointer, key), child)
+	}
+}
+
+func (walker openAPIWalker) pathItem(pointer string, object map[string]interface{}) {
+	walker.eachItem(jsonpointer.Append(pointer, "parameters"), object["parameters"], walker.parameter)
+	for _, method := range operationMethods {
+		walker.field(pointer, object, method, walker.operation)
+	}
+}
+
+func (walker openAPIWalker) operation(pointer string, object map[string]interface{}) {
+	walker.eachItem(jsonpointer.Append(pointer, "parameters"), object["parameters"], walker.parameter)
+	walker.field(pointer, object, "requestBody", walker.requestBody)
+	walker.each(jsonpointer.Append(pointer, "responses"), object["responses"], true, walker.response)
+	walker.each(jsonpointer.Append(pointer, "callbacks"), object["callbacks"], true, walker.callback)
+}
+
+func (walker openAPIWalker) callback(pointer string, object map[string]interface{}) {
+	walker.each(pointer, object, true, walker.pathItem)
+}
+
+// Parameters of OpenAPI 2.0 other than `body` describe their type without a Schema Object.
+func (walker openAPIWalker) parameter(pointer string, object map[string]interface{}) {
+	walker.field(pointer, object, "schema", walker.schema)
+	walker.each(jsonpointer.Append(pointer, "content"), object["content"], false, walker.mediaType)
+}
+
+func (walker openAPIWalker) header(pointer string, object map[string]interface{}) {
+	walker.field(pointer, object, "schema", walker.schema)
+	walker.each(jsonpointer.Append(pointer, "content"), object["content"], false, walker.mediaType)
+}
+
+func (walker openAPIWalker) requestBody(pointer string, object map[string]interface{}) {
+	walker.each(jsonpointer.Append(pointer, "content"), object["content"], false, walker.mediaType)
+}
+
+func (walker openAPIWalker) response(pointer string, object map[string]interface{}) {
+	walker.field(pointer, object, "schema", walker.schema)
+	walker.each(jsonpointer.Append(pointer, "headers"), object["headers"], true, walker.header)
+	walker.each(jsonpointer.Append(pointer, "content"), object["content"], false, walker.mediaType)
+}
+
+func (walker openAPIWalker) mediaType(pointer string, object map[string]interface{}) {
+	walker.field(pointer, object, "schema", walker.schema)
+	walker.each(jsonpointer.Append(pointer, "encoding"), object["encoding"], false, func(pointer string, encoding map[string]interface{}) {
+		walker.each(jsonpointer.Append(pointer, "headers"), encoding["headers"], true, walker.header)
+	})
+}
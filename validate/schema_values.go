@@ -0,0 +1,136 @@
+package validate
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/jsonpointer"
+	"github.com/clearcodehq/openapi-linter/references"
+	"github.com/clearcodehq/openapi-linter/report"
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// Name of the check reported with every invalid value embedded in a schema
+const SchemaValuesRule = "valid-schema-values"
+
+// Describes the check in reports that list rules
+const SchemaValuesRuleDescription = "The `example`, `examples` and `default` values of schemas must be valid against the schema."
+
+// Keywords of the sub-schemas, by the shape of their value
+var (
+	subSchemaKeywords      = []string{"additionalItems", "additionalProperties", "contains", "else", "if", "items", "not", "propertyNames", "then", "unevaluatedItems", "unevaluatedProperties"}
+	subSchemaMapKeywords   = []string{"$defs", "definitions", "dependencies", "dependentSchemas", "patternProperties", "properties"}
+	subSchemaArrayKeywords = []string{"allOf", "anyOf", "items", "oneOf", "prefixItems"}
+)
+
+// Calls visit with the schema and all its sub-schemas (e.g. properties, items, allOf) and the JSON Pointers to them.
+// The schema is visited first, sub-schemas are visited in the order of keywords.
+func EachSubSchema(pointer string, schema map[string]interface{}, visit func(pointer string, schema map[string]interface{})) {
+	visit(pointer, schema)
+	for _, keyword := range subSchemaKeywords {
+		if subSchema, isObject := schema[keyword].(map[string]interface{}); isObject {
+			EachSubSchema(jsonpointer.Append(pointer, keyword), subSchema, visit)
+		}
+	}
+	for _, keyword := range subSchemaMapKeywords {
+		subSchemas, _ := schema[keyword].(map[string]interface{})
+		keys := make([]string, 0, len(subSchemas))
+		for key := range subSchemas {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			// `dependencies` can list property names instead of a schema
+			if subSchema, isObject := subSchemas[key].(map[string]interface{}); isObject {
+				EachSubSchema(jsonpointer.Append(jsonpointer.Append(pointer, keyword), key), subSchema, visit)
+			}
+		}
+	}
+	for _, keyword := range subSchemaArrayKeywords {
+		subSchemas, _ := schema[keyword].([]interface{})
+		for index, item := range subSchemas {
+			if subSchema, isObject := item.(map[string]interface{}); isObject {
+				EachSubSchema(jsonpointer.Append(jsonpointer.Append(pointer, keyword), strconv.Itoa(index)), subSchema, visit)
+			}
+		}
+	}
+}
+
+// Validates the values embedded in the schema at the pointer of the file, and in all its sub-schemas,
+// against the (sub-)schema that holds them: `example`, `default` and every item of the `examples` array.
+// Returns errors with JsonPointer pointing to the (sub-)schema and Field pointing to the invalid value.
+func ValidateSchemaValues(store *document.Store, filePath string, pointer string, schema map[string]interface{}, checks *[]report.Check) []ValidationError {
+	validationErrors := []ValidationError{}
+	EachSubSchema(pointer, schema, func(pointer string, subSchema map[string]interface{}) {
+		validationErrors = append(validationErrors, validateSchemaValues(store, filePath, pointer, subSchema, checks)...)
+	})
+	return validationErrors
+}
+
+// Works like ValidateSchemaValues, sub-schemas aren't visited.
+// Schemas without values aren't checked, schemas that can't be compiled are skipped (they're reported by the Rule),
+// so are schemas with reference cycles that can't be satisfied.
+func validateSchemaValues(store *document.Store, filePath string, pointer string, schema map[string]interface{}, checks *[]report.Check) []ValidationError {
+	values := map[string]interface{}{}
+	for _, keyword := range []string{"default", "example"} {
+		if value, found := schema[keyword]; found {
+			values[jsonpointer.Append("", keyword)] = value
+		}
+	}
+	// `examples` of OpenAPI 3.0 schemas isn't a keyword, only the JSON Schema array is checked
+	examples, _ := schema["examples"].([]interface{})
+	for index, value := range examples {
+		values[jsonpointer.Append("/examples", strconv.Itoa(index))] = value
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	if checks != nil {
+		*checks = append(*checks, report.Check{File: filePath, Pointer: pointer, Rule: SchemaValuesRule})
+	}
+	// gojsonschema never finishes the validation against such schemas, the cycles are reported by the references.CycleRule
+	if len(references.FindCycles(store, document.URI(filePath), pointer)) > 0 {
+		return nil
+	}
+	compiledSchema, err := gojsonschema.NewSchema(schemaLoader(store, filePath, pointer))
+	if err != nil {
+		return nil
+	}
+
+	fields := make([]string, 0, len(values))
+	for field := range values {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	validationErrors := []ValidationError{}
+	for _, field := range fields {
+		result, err := compiledSchema.Validate(gojsonschema.NewGoLoader(values[field]))
+		if err != nil {
+			validationErrors = append(validationErrors, ValidationError{FilePath: filePath, JsonPointer: pointer, Field: field, Rule: SchemaValuesRule, Err: err})
+			continue
+		}
+		for _, validationError := range ResultErrors(result, values[field]) {
+			validationError.FilePath, validationError.JsonPointer = filePath, pointer
+			validationError.Field = field + validationError.Field
+			validationError.Rule = SchemaValuesRule
+			validationErrors = append(validationErrors, validationError)
+		}
+	}
+	return validationErrors
+}
+
+// Returns the loader of the schema at the pointer of the file.
+// The schema is loaded from the store by the URI of the file, so its `$ref`s are resolved relative to the file.
+// `nullable` of OpenAPI 3.0 schemas is translated, see SchemaFileSystem.
+func schemaLoader(store *document.Store, filePath string, pointer string) gojsonschema.JSONLoader {
+	reference, err := url.Parse(document.URI(filePath))
+	if err != nil {
+		reference = &url.URL{Scheme: "file", Path: filePath}
+	}
+	reference.Fragment = pointer
+	return gojsonschema.NewReferenceLoaderFileSystem(reference.String(), SchemaFileSystem(store, filePath))
+}
@@ -1,47 +1,136 @@
 package validate
 
 import (
-	"encoding/json"
 	"path/filepath"
 	"strings"
 
 	"fmt"
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/jsonpointer"
+	"github.com/clearcodehq/openapi-linter/references"
+	"github.com/clearcodehq/openapi-linter/report"
 	"github.com/xeipuuv/gojsonschema"
-	"io/ioutil"
 	"os"
 	"reflect"
+	"sort"
+	"strconv"
 )
 
+// Name of the check reported with every validation error
+const Rule = "valid-schema"
+
+// Describes the check in reports that list rules
+const RuleDescription = "Nested JSON objects must be valid JSON Schemas."
+
 // Store informations about a validation error
+// JsonPointer points to the validated object, Field is a JSON Pointer to the invalid value relative to that object.
+// Rule names the check that found the error, the package level Rule is used if it's empty.
 type ValidationError struct {
 	FilePath string
-	JsonPath string
+	JsonPointer string
+	Field string
+	Line int
+	Column int
+	Rule string
 	Err error
 }
 
+// Converts the validation error to the report finding.
+// Files that can't be parsed are reported with the document.ParseErrorRule.
+func (validationError ValidationError) Finding() report.Finding {
+	rule := Rule
+	if len(validationError.Rule) > 0 {
+		rule = validationError.Rule
+	}
+	if _, isParseError := validationError.Err.(*document.ParseError); isParseError {
+		rule = document.ParseErrorRule
+	}
+	return report.Finding{
+		File: validationError.FilePath,
+		Pointer: validationError.Pointer(),
+		Line: validationError.Line,
+		Column: validationError.Column,
+		Rule: rule,
+		Message: validationError.Err.Error(),
+		Severity: report.SeverityError,
+	}
+}
+
+// Returns the validation error of a file that can't be read or parsed, at the position of the syntax error.
+func FileError(filePath string, err error) ValidationError {
+	fileError := ValidationError{FilePath: filePath, Err: err}
+	if parseError, isParseError := err.(*document.ParseError); isParseError {
+		fileError.Line, fileError.Column = parseError.Line, parseError.Column
+	}
+	return fileError
+}
+
+// Returns the JSON Pointer to the invalid value within the file.
+func (validationError ValidationError) Pointer() string {
+	return validationError.JsonPointer + validationError.Field
+}
+
 // Recursively iterates over jsonObject and tries to validate all nested objects as JSON object schemas.
+// Values embedded in the schemas (`example`, `examples` and `default`) are validated against the schemas too.
+// Objects nested in arrays (e.g. `allOf`, `oneOf`, `parameters`) are validated too.
+// Objects are visited in the order of their keys, so errors are always returned in the same order.
 // Returns the list of validation errors if found
-func TraverseJSONObject(filePath string, jsonPath string, jsonObject map[string] interface{}, errors *map[string] ValidationError){
-	for key, value := range jsonObject {
-		childPath := jsonPath
-		objectType := reflect.ValueOf(value).Kind()
-
-		if objectType.String() == "map" {
-			childPath = fmt.Sprintf("%s.%s", jsonPath, key)
-			childObject := value.(map[string] interface{})
-
-			TraverseJSONObject(filePath, childPath, childObject, errors)
-		} else {
-			validationKey := fmt.Sprintf("%s:%s", filePath, jsonPath)
-			_, wasValidated := (*errors) [validationKey]
-			if !wasValidated {
-				err := ValidateSchema(&jsonObject);
-				if err != nil {
-					(*errors) [validationKey] = ValidationError{filePath, jsonPath, err}
-				}
-			}
+func TraverseJSONObject(filePath string, jsonPointer string, jsonObject map[string] interface{}, errors *[]ValidationError){
+	traverseJSONObject(document.NewStore(), filePath, jsonPointer, jsonObject, errors, nil)
+}
+
+// Works like TraverseJSONObject and additionally records every validated object in checks (if not nil).
+// Referenced documents are loaded from the store.
+func traverseJSONObject(store *document.Store, filePath string, jsonPointer string, jsonObject map[string] interface{}, errors *[]ValidationError, checks *[]report.Check){
+	keys := make([]string, 0, len(jsonObject))
+	for key := range jsonObject {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	if IsSchema(jsonObject) {
+		addSchemaErrors(filePath, jsonPointer, ValidateSchema(&jsonObject), errors, checks)
+		*errors = append(*errors, validateSchemaValues(store, filePath, jsonPointer, jsonObject, checks)...)
+	}
+
+	for _, key := range keys {
+		traverseJSONValue(store, filePath, jsonpointer.Append(jsonPointer, key), jsonObject[key], errors, checks)
+	}
+}
+
+// Determines if the object is validated as a schema by TraverseJSONObject: objects holding any value that isn't an object
+// are schemas, objects holding only objects (e.g. `definitions`) are containers of schemas.
+func IsSchema(jsonObject map[string] interface{}) bool {
+	for _, value := range jsonObject {
+		if reflect.ValueOf(value).Kind().String() != "map" {
+			return true
 		}
 	}
+	return false
+}
+
+// Traverses objects and arrays, array items are visited with their index as the pointer segment.
+// e.g. /allOf/2/properties/id
+func traverseJSONValue(store *document.Store, filePath string, jsonPointer string, value interface{}, errors *[]ValidationError, checks *[]report.Check){
+	switch child := value.(type) {
+	case map[string] interface{}:
+		traverseJSONObject(store, filePath, jsonPointer, child, errors, checks)
+	case [] interface{}:
+		for index, item := range child {
+			traverseJSONValue(store, filePath, jsonpointer.Append(jsonPointer, strconv.Itoa(index)), item, errors, checks)
+		}
+	}
+}
+
+// Records the check of the schema object at jsonPointer (if checks is not nil) and its validation errors.
+func addSchemaErrors(filePath string, jsonPointer string, validationErrors []ValidationError, errors *[]ValidationError, checks *[]report.Check){
+	if checks != nil {
+		*checks = append(*checks, report.Check{File: filePath, Pointer: jsonPointer, Rule: Rule})
+	}
+	for _, validationError := range validationErrors {
+		validationError.FilePath, validationError.JsonPointer = filePath, jsonPointer
+		*errors = append(*errors, validationError)
+	}
 }
 
 
@@ -64,12 +153,25 @@ func IsJsonFile(fileInfo JsonFileInfo) bool {
 	return false
 }
 
-// Scans the directory and returns only JSON files
+// Determines if file is a JSON or YAML document.
+func IsDocumentFile(fileInfo JsonFileInfo) bool {
+	if fileInfo.IsDir() == true {
+		return false
+	}
+	return document.IsSupported(fileInfo.Name())
+}
+
+// Scans the directory and returns only JSON and YAML files
+// Returns an error if the directory or any directory within it can't be read.
 func FindJsonFiles(dir string) ([] string, error) {
 	paths := [] string {}
 
 	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
-		if info != nil && IsJsonFile(info) {
+		// e.g. the directory doesn't exist
+		if err != nil {
+			return err
+		}
+		if IsDocumentFile(info) {
 			paths = append(paths, path)
 		}
 		return nil
@@ -77,55 +179,196 @@ func FindJsonFiles(dir string) ([] string, error) {
 	return paths, err
 }
 // validates all schemas within given directory
-func ValidateAllSchemasInDir(dir string) (map[string]ValidationError, error) {
+// Files that can't be read or parsed are reported as validation errors and don't stop the scan.
+// Returns validation errors sorted by the file, position and rule, and the list of all validated objects.
+func ValidateAllSchemasInDir(dir string, mode Mode) ([]ValidationError, []report.Check, error) {
+	return ValidateAllSchemasInStore(document.NewStore(), dir, mode)
+}
+
+// Works like ValidateAllSchemasInDir, documents are loaded from the store.
+func ValidateAllSchemasInStore(store *document.Store, dir string, mode Mode) ([]ValidationError, []report.Check, error) {
 
 	jsonFiles, err := FindJsonFiles(dir)
 	if err != nil {
-		return map[string] ValidationError{}, err
+		return nil, nil, err
 	}
 
-	jsonErrors := make(map[string] ValidationError)
+	jsonErrors := [] ValidationError{}
+	checks := [] report.Check{}
+	// Every document is read and parsed once, references between documents are resolved in memory
 	for _, file := range jsonFiles {
-			if fileErr := ValidateJSONFile(file, &jsonErrors); fileErr != nil {
-				return map[string] ValidationError{}, fileErr
+			if fileErr := ValidateJSONFileInStore(store, file, mode, &jsonErrors, &checks); fileErr != nil {
+				jsonErrors = append(jsonErrors, FileError(file, fileErr))
 			}
 		}
-	return jsonErrors, err
+	sort.SliceStable(jsonErrors, func(i, j int) bool {
+		return report.Less(jsonErrors[i].Finding(), jsonErrors[j].Finding())
+	})
+	return jsonErrors, checks, err
 }
 
 // Opens a file and unmarshals its content and validates it.
-func ValidateJSONFile(schemaPath string, jsonErrors *map[string] ValidationError) error {
-	jsonFile, err := os.Open(schemaPath)
+// In the ModeOpenAPI only the schema locations of OpenAPI documents are validated.
+// Validated objects are recorded in checks (if not nil).
+func ValidateJSONFile(schemaPath string, mode Mode, jsonErrors *[]ValidationError, checks *[]report.Check) error {
+	return ValidateJSONFileInStore(document.NewStore(), schemaPath, mode, jsonErrors, checks)
+}
 
+// Works like ValidateJSONFile, the file and the documents it references are loaded from the store.
+// Files validated with the same store are read and parsed once.
+func ValidateJSONFileInStore(store *document.Store, schemaPath string, mode Mode, jsonErrors *[]ValidationError, checks *[]report.Check) error {
+	jsonDocument, err := store.Load(schemaPath)
 	if err != nil {
 		return err
 	}
-	jsonBytes, err  := ioutil.ReadAll(jsonFile);
 
-	if err != nil {
-		return err
+	firstError := len(*jsonErrors)
+	if version := OpenAPIVersion(jsonDocument.Root); mode == ModeOpenAPI && version != "" {
+		validateOpenAPISchemas(store, schemaPath, version, jsonDocument.Root.(map[string] interface{}), jsonErrors, checks)
+	} else {
+		traverseJSONValue(store, schemaPath, "", jsonDocument.Root, jsonErrors, checks)
 	}
+	validateReferenceCycles(store, schemaPath, jsonErrors, checks)
 
-	jsonSchema := map[string] interface {}{}
+	for index := firstError; index < len(*jsonErrors); index++ {
+		position := jsonDocument.Position((*jsonErrors)[index].Pointer())
+		(*jsonErrors)[index].Line, (*jsonErrors)[index].Column = position.Line, position.Column
+	}
+	return nil;
+}
 
-	err = json.Unmarshal(jsonBytes, &jsonSchema)
-	if err != nil {
-		return err
+// Reports the reference cycles of the file that can't be satisfied, at the first node of every cycle.
+func validateReferenceCycles(store *document.Store, filePath string, errors *[]ValidationError, checks *[]report.Check) {
+	if checks != nil {
+		*checks = append(*checks, report.Check{File: filePath, Rule: references.CycleRule})
+	}
+	uri := document.URI(filePath)
+	for _, cycle := range references.FindDocumentCycles(store, uri) {
+		*errors = append(*errors, ValidationError{
+			FilePath: filePath,
+			JsonPointer: cycle[0].Pointer,
+			Rule: references.CycleRule,
+			Err: fmt.Errorf("reference cycle that can't be satisfied: %s", references.FormatCycle(uri, cycle)),
+		})
 	}
+}
 
-	TraverseJSONObject( schemaPath, "", jsonSchema, jsonErrors)
+// Compiled meta-schemas, by their URL
+var metaSchemas = map[string] *gojsonschema.Schema{}
 
-	defer jsonFile.Close()
-	return nil;
+// Check the object is a valid JSON Schema.
+// Objects that don't declare `$schema` are only checked for invalid references.
+// Returns all violations of the meta-schema, with Field pointing to the invalid value.
+func ValidateSchema(schemaContent *map[string] interface{}) ([]ValidationError) {
+	return validateSchema(schemaContent, nil)
 }
 
-// Check the object is a valid JSON Schema.
-func ValidateSchema(schemaContent *map[string] interface{}) (error) {
+// Works like ValidateSchema, objects that don't declare `$schema` are validated against the defaultSchemaURL (if not nil).
+func validateSchema(schemaContent *map[string] interface{}, defaultSchemaURL interface{}) ([]ValidationError) {
+	validationErrors := [] ValidationError{}
+
+	schemaURL, declared := (*schemaContent)["$schema"]
+	if !declared {
+		schemaURL = defaultSchemaURL
+	}
+	if declared || schemaURL != nil {
+		metaSchema, err := getMetaSchema(schemaURL)
+		if err != nil {
+			return append(validationErrors, ValidationError{Field: "/$schema", Err: err})
+		}
+
+		result, err := metaSchema.Validate(gojsonschema.NewGoLoader(schemaContent))
+		if err != nil {
+			return append(validationErrors, ValidationError{Err: err})
+		}
+		validationErrors = append(validationErrors, ResultErrors(result, *schemaContent)...)
+	}
+
 	schemaLoader := gojsonschema.NewSchemaLoader()
-	schemaLoader.Validate = true
 	fileLoader := gojsonschema.NewGoLoader(schemaContent)
 	if err := schemaLoader.AddSchemas(fileLoader); err != nil {
-		return err
+		validationErrors = append(validationErrors, ValidationError{Err: err})
 	}
-	return nil;
-}
\ No newline at end of file
+	return validationErrors;
+}
+
+// Converts errors of the validation result, with Field pointing to the invalid value, sorted by the Field.
+// The value is the validated object, it's needed to translate the error context to a JSON Pointer.
+func ResultErrors(result *gojsonschema.Result, value interface{}) []ValidationError {
+	validationErrors := [] ValidationError{}
+	for _, resultError := range result.Errors() {
+		context := strings.TrimPrefix(resultError.Context().String("/"), "(root)")
+		field, found := contextToPointer(value, context)
+		if !found {
+			field = context
+		}
+		validationErrors = append(validationErrors, ValidationError{
+			Field: field,
+			Err: fmt.Errorf("%s", resultError.String()),
+		})
+	}
+	// gojsonschema reports errors in a random order
+	sort.SliceStable(validationErrors, func(i, j int) bool {
+		if validationErrors[i].Field != validationErrors[j].Field {
+			return validationErrors[i].Field < validationErrors[j].Field
+		}
+		return validationErrors[i].Err.Error() < validationErrors[j].Err.Error()
+	})
+	return validationErrors
+}
+
+// Translates the context of a gojsonschema error to a JSON Pointer.
+// gojsonschema joins keys with `/` without escaping them, so keys are matched against the validated value.
+// e.g. /paths//users/get -> /paths/~1users/get
+func contextToPointer(value interface{}, context string) (string, bool) {
+	if len(context) == 0 {
+		return "", true
+	}
+	if !strings.HasPrefix(context, "/") {
+		return "", false
+	}
+	context = context[1:]
+
+	switch node := value.(type) {
+	case map[string] interface{}:
+		keys := make([]string, 0, len(node))
+		for key := range node {
+			if context == key || strings.HasPrefix(context, key + "/") {
+				keys = append(keys, key)
+			}
+		}
+		// longer keys first, e.g. `a/b` before `a`
+		sort.Slice(keys, func(i, j int) bool { return len(keys[i]) > len(keys[j]) })
+		for _, key := range keys {
+			if pointer, found := contextToPointer(node[key], context[len(key):]); found {
+				return jsonpointer.Append("", key) + pointer, true
+			}
+		}
+	case [] interface{}:
+		segment := strings.SplitN(context, "/", 2)[0]
+		if index, err := strconv.Atoi(segment); err == nil && index >= 0 && index < len(node) {
+			if pointer, found := contextToPointer(node[index], context[len(segment):]); found {
+				return "/" + segment + pointer, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Loads and compiles the meta-schema declared by `$schema`.
+func getMetaSchema(schemaURL interface{}) (*gojsonschema.Schema, error) {
+	url, isString := schemaURL.(string)
+	if !isString {
+		return nil, fmt.Errorf("$schema must be of type string")
+	}
+
+	if metaSchema, loaded := metaSchemas[url]; loaded {
+		return metaSchema, nil
+	}
+	metaSchema, err := gojsonschema.NewSchema(gojsonschema.NewReferenceLoader(url))
+	if err != nil {
+		return nil, err
+	}
+	metaSchemas[url] = metaSchema
+	return metaSchema, nil
+}
@@ -1,16 +1,23 @@
 package validate_test
 
 import (
+	"github.com/clearcodehq/openapi-linter/document"
+	"github.com/clearcodehq/openapi-linter/references"
+	"github.com/clearcodehq/openapi-linter/report"
 	"github.com/clearcodehq/openapi-linter/validate"
 	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
+	"runtime"
+	"sort"
 	"testing"
 )
 
 func TestValidateJSONFile(t *testing.T) {
 	t.Run("Empty object", func(t *testing.T) {
 		// GIVEN
-		jsonErrors := make(map[string]validate.ValidationError)
+		jsonErrors := []validate.ValidationError{}
 		jsonSchema := map[string]interface{}{}
 
 		// WHEN
@@ -23,7 +30,7 @@ func TestValidateJSONFile(t *testing.T) {
 	})
 	t.Run("The root object is a schema", func(t *testing.T) {
 		// GIVEN
-		jsonErrors := make(map[string]validate.ValidationError)
+		jsonErrors := []validate.ValidationError{}
 		jsonSchema := map[string]interface{}{
 			"$schema": "http://json-schema.org/draft-07/schema",
 			"properties": map[string]interface{}{
@@ -43,14 +50,21 @@ func TestValidateJSONFile(t *testing.T) {
 	})
 	t.Run("The root object is a schema and has an error", func(t *testing.T) {
 		// GIVEN
-		expectedErrors := map[string]validate.ValidationError{
-			"xxx.json:root": validate.ValidationError{
-				"xxx.json",
-				"root",
-				fmt.Errorf("properties.evilfield.type: Must validate at least one schema (anyOf)\nproperties.evilfield.type: properties.evilfield.type must be one of the following: \"array\", \"boolean\", \"integer\", \"null\", \"number\", \"object\", \"string\"\n"),
+		expectedErrors := []validate.ValidationError{
+			{
+				FilePath: "xxx.json",
+				JsonPointer: "root",
+				Field: "/properties/evilfield/type",
+				Err: fmt.Errorf("properties.evilfield.type: Must validate at least one schema (anyOf)"),
+			},
+			{
+				FilePath: "xxx.json",
+				JsonPointer: "root",
+				Field: "/properties/evilfield/type",
+				Err: fmt.Errorf("properties.evilfield.type: properties.evilfield.type must be one of the following: \"array\", \"boolean\", \"integer\", \"null\", \"number\", \"object\", \"string\""),
 			},
 		}
-		jsonErrors := make(map[string]validate.ValidationError)
+		jsonErrors := []validate.ValidationError{}
 		jsonSchema := map[string]interface{}{
 			"$schema": "http://json-schema.org/draft-07/schema",
 			"properties": map[string]interface{}{
@@ -69,21 +83,35 @@ func TestValidateJSONFile(t *testing.T) {
 		}
 	})
 
-	t.Run("The root object is a schema and has an error", func(t *testing.T) {
+	t.Run("Nested schemas have errors", func(t *testing.T) {
 		// GIVEN
-		expectedErrors := map[string]validate.ValidationError{
-			"xxx.json:.200": validate.ValidationError{
-				"xxx.json",
-				".200",
-				fmt.Errorf("properties.evilfield.type: Must validate at least one schema (anyOf)\nproperties.evilfield.type: properties.evilfield.type must be one of the following: \"array\", \"boolean\", \"integer\", \"null\", \"number\", \"object\", \"string\"\n"),
+		expectedErrors := []validate.ValidationError{
+			{
+				FilePath: "xxx.json",
+				JsonPointer: "/200",
+				Field: "/properties/evilfield/type",
+				Err: fmt.Errorf("properties.evilfield.type: Must validate at least one schema (anyOf)"),
+			},
+			{
+				FilePath: "xxx.json",
+				JsonPointer: "/200",
+				Field: "/properties/evilfield/type",
+				Err: fmt.Errorf("properties.evilfield.type: properties.evilfield.type must be one of the following: \"array\", \"boolean\", \"integer\", \"null\", \"number\", \"object\", \"string\""),
+			},
+			{
+				FilePath: "xxx.json",
+				JsonPointer: "/400",
+				Field: "/properties/evilfield/type",
+				Err: fmt.Errorf("properties.evilfield.type: Must validate at least one schema (anyOf)"),
 			},
-			"xxx.json:.400": validate.ValidationError{
-				"xxx.json",
-				".400",
-				fmt.Errorf("properties.evilfield.type: Must validate at least one schema (anyOf)\nproperties.evilfield.type: properties.evilfield.type must be one of the following: \"array\", \"boolean\", \"integer\", \"null\", \"number\", \"object\", \"string\"\n"),
+			{
+				FilePath: "xxx.json",
+				JsonPointer: "/400",
+				Field: "/properties/evilfield/type",
+				Err: fmt.Errorf("properties.evilfield.type: properties.evilfield.type must be one of the following: \"array\", \"boolean\", \"integer\", \"null\", \"number\", \"object\", \"string\""),
 			},
 		}
-		jsonErrors := make(map[string]validate.ValidationError)
+		jsonErrors := []validate.ValidationError{}
 		jsonSchema := map[string]interface{}{
 			"200": map[string]interface{}{
 				"$schema": "http://json-schema.org/draft-07/schema",
@@ -111,6 +139,383 @@ func TestValidateJSONFile(t *testing.T) {
 			t.Errorf("AssertionFail: %+v != %+v", jsonErrors, expectedErrors)
 		}
 	})
+
+	t.Run("Keys with slashes are escaped in pointers", func(t *testing.T) {
+		// GIVEN
+		jsonErrors := []validate.ValidationError{}
+		jsonSchema := map[string]interface{}{
+			"$schema": "http://json-schema.org/draft-07/schema",
+			"properties": map[string]interface{}{
+				"a/b": map[string]interface{}{"minLength": -1},
+			},
+		}
+
+		// WHEN
+		validate.TraverseJSONObject("xxx.json", "", jsonSchema, &jsonErrors)
+
+		// THEN
+		if len(jsonErrors) == 0 || jsonErrors[0].Field != "/properties/a~1b/minLength" {
+			t.Errorf("AssertionFail: %+v", jsonErrors)
+		}
+	})
+
+	t.Run("Schemas nested in arrays", func(t *testing.T) {
+		// GIVEN
+		expectedPointers := []string{
+			"/definitions/user/allOf/1/properties/id/type",
+			"/definitions/user/allOf/1/properties/id/type",
+			"/parameters/0/schema/items",
+			"/parameters/0/schema/items/0/minimum",
+		}
+		jsonErrors := []validate.ValidationError{}
+		jsonSchema := map[string]interface{}{
+			"definitions": map[string]interface{}{
+				"user": map[string]interface{}{
+					"allOf": []interface{}{
+						map[string]interface{}{"$ref": "#/definitions/base"},
+						map[string]interface{}{
+							"$schema": "http://json-schema.org/draft-07/schema",
+							"properties": map[string]interface{}{
+								"id": map[string]interface{}{"type": "unsupported type"},
+							},
+						},
+					},
+				},
+			},
+			"parameters": []interface{}{
+				map[string]interface{}{
+					"name": "limit",
+					"schema": map[string]interface{}{
+						"$schema": "http://json-schema.org/draft-07/schema",
+						"items": []interface{}{
+							map[string]interface{}{"minimum": "zero"},
+						},
+					},
+				},
+			},
+		}
+
+		// WHEN
+		validate.TraverseJSONObject("xxx.json", "", jsonSchema, &jsonErrors)
+
+		// THEN
+		pointers := []string{}
+		for _, jsonError := range jsonErrors {
+			pointers = append(pointers, jsonError.Pointer())
+		}
+		if !reflect.DeepEqual(pointers, expectedPointers) {
+			t.Errorf("AssertionFail: %+v != %+v", pointers, expectedPointers)
+		}
+	})
+}
+
+func TestValidateJSONFileReportsPositions(t *testing.T) {
+	testCases := []struct{
+		fileName string
+		line int
+		column int
+	}{
+		{"invalid_schema.json", 7, 19},
+		{"invalid_schema.yaml", 6, 15},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.fileName, func(t *testing.T) {
+			// GIVEN
+			_, testsFile, _, _ := runtime.Caller(0)
+			schemaPath := filepath.Join(filepath.Dir(testsFile), "..", "tests", "validate", "validate_json_file", testCase.fileName)
+			jsonErrors := []validate.ValidationError{}
+
+			// WHEN
+			err := validate.ValidateJSONFile(schemaPath, validate.ModeHeuristic, &jsonErrors, nil)
+
+			// THEN
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if len(jsonErrors) != 2 {
+				t.Fatalf("Expected 2 validation errors: %+v", jsonErrors)
+			}
+			validationError := jsonErrors[0]
+			if validationError.Line != testCase.line || validationError.Column != testCase.column {
+				t.Errorf("AssertionFail: %d:%d != %d:%d", validationError.Line, validationError.Column, testCase.line, testCase.column)
+			}
+		})
+	}
+}
+
+func TestValidateJSONFileInOpenAPIMode(t *testing.T) {
+	testCases := []struct{
+		fileName string
+		expectedChecks []string
+		expectedPointers []string
+	}{
+		{
+			"openapi.yaml",
+			[]string{
+				"/paths/~1users~1{id}/parameters/0/schema",
+				"/paths/~1users~1{id}/get/responses/200/headers/X-Rate-Limit/schema",
+				"/paths/~1users~1{id}/get/responses/200/content/application~1vnd.api+json/schema",
+				"/components/schemas/User",
+			},
+			[]string{
+				"/paths/~1users~1{id}/get/responses/200/headers/X-Rate-Limit/schema/minLength",
+				"/paths/~1users~1{id}/get/responses/200/headers/X-Rate-Limit/schema/minLength",
+				"/components/schemas/User/properties/name/type",
+				"/components/schemas/User/properties/name/type",
+				"/components/schemas/User/required",
+			},
+		},
+		{
+			"swagger.json",
+			[]string{
+				"/paths/~1users/post/parameters/0/schema",
+				"/paths/~1users/post/responses/200/schema",
+				"/definitions/User",
+			},
+			[]string{
+				"/definitions/User/maxProperties",
+			},
+		},
+		{
+			"schema.json",
+			[]string{""},
+			[]string{"/minLength", "/minLength"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.fileName, func(t *testing.T) {
+			// GIVEN
+			_, testsFile, _, _ := runtime.Caller(0)
+			schemaPath := filepath.Join(filepath.Dir(testsFile), "..", "tests", "validate", "openapi_mode", testCase.fileName)
+			jsonErrors := []validate.ValidationError{}
+			checks := []report.Check{}
+
+			// WHEN
+			err := validate.ValidateJSONFile(schemaPath, validate.ModeOpenAPI, &jsonErrors, &checks)
+
+			// THEN
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			checkedPointers := []string{}
+			for _, check := range checks {
+				if check.Rule == validate.Rule {
+					checkedPointers = append(checkedPointers, check.Pointer)
+				}
+			}
+			if !reflect.DeepEqual(checkedPointers, testCase.expectedChecks) {
+				t.Errorf("AssertionFail: %+v != %+v", checkedPointers, testCase.expectedChecks)
+			}
+			pointers := []string{}
+			for _, jsonError := range jsonErrors {
+				pointers = append(pointers, jsonError.Pointer())
+			}
+			if !reflect.DeepEqual(pointers, testCase.expectedPointers) {
+				t.Errorf("AssertionFail: %+v != %+v", pointers, testCase.expectedPointers)
+			}
+		})
+	}
+}
+
+func TestValidateJSONFileValidatesSchemaValues(t *testing.T) {
+	testCases := []struct{
+		fileName string
+		mode validate.Mode
+		expectedFindings []string
+	}{
+		{
+			"openapi.yaml",
+			validate.ModeOpenAPI,
+			[]string{
+				"26:17 /components/schemas/User/example/address/city",
+				"14:20 /components/schemas/User/properties/id/default",
+				"19:15 /components/schemas/User/properties/name/examples/1",
+			},
+		},
+		{
+			"schema.json",
+			validate.ModeHeuristic,
+			[]string{"8:20 /default/1"},
+		},
+		{
+			"nullable.yaml",
+			validate.ModeOpenAPI,
+			[]string{"26:20 /components/schemas/User/properties/email/default"},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.fileName, func(t *testing.T) {
+			// GIVEN
+			_, testsFile, _, _ := runtime.Caller(0)
+			schemaPath := filepath.Join(filepath.Dir(testsFile), "..", "tests", "validate", "schema_values", testCase.fileName)
+			jsonErrors := []validate.ValidationError{}
+
+			// WHEN
+			err := validate.ValidateJSONFile(schemaPath, testCase.mode, &jsonErrors, nil)
+
+			// THEN
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			findings := []string{}
+			for _, jsonError := range jsonErrors {
+				if jsonError.Finding().Rule != validate.SchemaValuesRule {
+					t.Errorf("Unexpected error: %+v", jsonError)
+				}
+				findings = append(findings, fmt.Sprintf("%d:%d %s", jsonError.Line, jsonError.Column, jsonError.Pointer()))
+			}
+			if !reflect.DeepEqual(findings, testCase.expectedFindings) {
+				t.Errorf("AssertionFail: %+v != %+v", findings, testCase.expectedFindings)
+			}
+		})
+	}
+}
+
+func TestValidateJSONFileReportsReferenceCycles(t *testing.T) {
+	// GIVEN
+	_, testsFile, _, _ := runtime.Caller(0)
+	schemaPath := filepath.Join(filepath.Dir(testsFile), "..", "tests", "references", "cycles", "spec.yaml")
+	jsonErrors := []validate.ValidationError{}
+	expectedFindings := []string{
+		"17:5 /components/schemas/Self reference cycle that can't be satisfied: #/components/schemas/Self -> #/components/schemas/Self",
+		"19:5 /components/schemas/A reference cycle that can't be satisfied: #/components/schemas/A -> #/components/schemas/B -> #/components/schemas/B/allOf/1 -> #/components/schemas/A",
+	}
+
+	// WHEN
+	err := validate.ValidateJSONFile(schemaPath, validate.ModeOpenAPI, &jsonErrors, nil)
+
+	// THEN
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	findings := []string{}
+	for _, jsonError := range jsonErrors {
+		if jsonError.Finding().Rule != references.CycleRule {
+			t.Errorf("Unexpected error: %+v", jsonError)
+		}
+		findings = append(findings, fmt.Sprintf("%d:%d %s %s", jsonError.Line, jsonError.Column, jsonError.Pointer(), jsonError.Err))
+	}
+	sort.Strings(findings)
+	if !reflect.DeepEqual(findings, expectedFindings) {
+		t.Errorf("AssertionFail: %+v != %+v", findings, expectedFindings)
+	}
+}
+
+func TestEachSubSchema(t *testing.T) {
+	// GIVEN
+	schema := map[string]interface{}{
+		"properties": map[string]interface{}{
+			"b": map[string]interface{}{"items": map[string]interface{}{}},
+			"a": map[string]interface{}{"allOf": []interface{}{map[string]interface{}{}}},
+		},
+		"dependencies": map[string]interface{}{"a": []interface{}{"b"}},
+		"not": map[string]interface{}{},
+	}
+	expectedPointers := []string{"/s", "/s/not", "/s/properties/a", "/s/properties/a/allOf/0", "/s/properties/b", "/s/properties/b/items"}
+
+	// WHEN
+	pointers := []string{}
+	validate.EachSubSchema("/s", schema, func(pointer string, subSchema map[string]interface{}) {
+		pointers = append(pointers, pointer)
+	})
+
+	// THEN
+	if !reflect.DeepEqual(pointers, expectedPointers) {
+		t.Errorf("AssertionFail: %+v != %+v", pointers, expectedPointers)
+	}
+}
+
+func TestOpenAPIVersion(t *testing.T) {
+	testCases := []struct{
+		root interface{}
+		expectedVersion string
+	}{
+		{map[string]interface{}{"swagger": "2.0"}, validate.OpenAPI20},
+		{map[string]interface{}{"openapi": "3.0.3"}, validate.OpenAPI30},
+		{map[string]interface{}{"openapi": "3.1.0"}, validate.OpenAPI31},
+		{map[string]interface{}{"openapi": "3.10.0"}, ""},
+		{map[string]interface{}{"$schema": "http://json-schema.org/draft-07/schema"}, ""},
+		{[]interface{}{}, ""},
+	}
+	for _, testCase := range testCases {
+		if version := validate.OpenAPIVersion(testCase.root); version != testCase.expectedVersion {
+			t.Errorf("AssertionFail: %+v: %q != %q", testCase.root, version, testCase.expectedVersion)
+		}
+	}
+}
+
+func TestValidateAllSchemasInDir(t *testing.T) {
+	// GIVEN
+	_, testsFile, _, _ := runtime.Caller(0)
+	dir := filepath.Join(filepath.Dir(testsFile), "..", "tests", "validate", "validate_all_schemas_in_dir")
+	expectedLocations := []string{
+		filepath.Join(dir, "a.yaml") + ":4:16",
+		filepath.Join(dir, "a.yaml") + ":4:16",
+		filepath.Join(dir, "a.yaml") + ":7:15",
+		filepath.Join(dir, "b.json") + ":3:11",
+		filepath.Join(dir, "b.json") + ":3:11",
+		filepath.Join(dir, "nested", "c.json") + ":3:11",
+		filepath.Join(dir, "nested", "c.json") + ":3:11",
+	}
+
+	for run := 0; run < 5; run++ {
+		// WHEN
+		jsonErrors, _, err := validate.ValidateAllSchemasInDir(dir, validate.ModeHeuristic)
+
+		// THEN
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		locations := []string{}
+		for _, jsonError := range jsonErrors {
+			locations = append(locations, jsonError.Finding().Location())
+		}
+		if !reflect.DeepEqual(locations, expectedLocations) {
+			t.Errorf("AssertionFail: %+v != %+v", locations, expectedLocations)
+		}
+	}
+}
+
+func TestValidateAllSchemasInDirFailsOnMissingDirectory(t *testing.T) {
+	// GIVEN
+	_, testsFile, _, _ := runtime.Caller(0)
+	dir := filepath.Join(filepath.Dir(testsFile), "..", "tests", "validate", "missing")
+
+	// WHEN
+	_, _, err := validate.ValidateAllSchemasInDir(dir, validate.ModeHeuristic)
+
+	// THEN
+	if !os.IsNotExist(err) {
+		t.Errorf("AssertionFail: %v", err)
+	}
+}
+
+func TestValidateAllSchemasInDirContinuesPastUnparsableFiles(t *testing.T) {
+	// GIVEN
+	_, testsFile, _, _ := runtime.Caller(0)
+	dir := filepath.Join(filepath.Dir(testsFile), "..", "tests", "validate", "unparsable_files")
+	expectedFindings := []string{
+		filepath.Join(dir, "broken.json") + ":5:1 " + document.ParseErrorRule,
+		filepath.Join(dir, "broken.yaml") + ":2 " + document.ParseErrorRule,
+		filepath.Join(dir, "schema.json") + ":3:11 " + validate.Rule,
+		filepath.Join(dir, "schema.json") + ":3:11 " + validate.Rule,
+	}
+
+	// WHEN
+	jsonErrors, _, err := validate.ValidateAllSchemasInDir(dir, validate.ModeHeuristic)
+
+	// THEN
+	if err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+	findings := []string{}
+	for _, jsonError := range jsonErrors {
+		finding := jsonError.Finding()
+		findings = append(findings, finding.Location() + " " + finding.Rule)
+	}
+	if !reflect.DeepEqual(findings, expectedFindings) {
+		t.Errorf("AssertionFail: %+v != %+v", findings, expectedFindings)
+	}
 }
 
 type MockFileInfo struct {
@@ -164,3 +569,51 @@ func TestIsJsonFile(t *testing.T) {
 		}
 	})
 }
+
+func TestValidationErrorFinding(t *testing.T) {
+	t.Run("Join the schema pointer and the field", func(t *testing.T) {
+		// GIVEN
+		validationError := validate.ValidationError{
+			FilePath: "xxx.json",
+			JsonPointer: "/definitions/200",
+			Field: "/properties/evilfield/type",
+			Line: 3,
+			Column: 14,
+			Err: fmt.Errorf("properties.evilfield.type: Must validate at least one schema (anyOf)"),
+		}
+		expectedFinding := report.Finding{
+			File: "xxx.json",
+			Pointer: "/definitions/200/properties/evilfield/type",
+			Line: 3,
+			Column: 14,
+			Rule: validate.Rule,
+			Message: "properties.evilfield.type: Must validate at least one schema (anyOf)",
+			Severity: report.SeverityError,
+		}
+
+		// WHEN
+		finding := validationError.Finding()
+
+		// THEN
+		if !reflect.DeepEqual(finding, expectedFinding) {
+			t.Errorf("AssertionFail: %+v != %+v", finding, expectedFinding)
+		}
+	})
+}
+
+func TestIsDocumentFile(t *testing.T) {
+	t.Run("Find JSON and YAML files", func(t *testing.T) {
+		for _, name := range []string{"aaa.json", "aaa.yaml", "aaa.yml", "AAA.YAML"} {
+			if validate.IsDocumentFile(MockFileInfo{name, false}) != true {
+				t.Errorf("Document not found: %s", name)
+			}
+		}
+	})
+	t.Run("Skip other files and directories", func(t *testing.T) {
+		for _, file := range []MockFileInfo{{"aaa.txt", false}, {"aaa.yaml", true}} {
+			if validate.IsDocumentFile(file) != false {
+				t.Errorf("Not a document: %+v", file)
+			}
+		}
+	})
+}